@@ -6,6 +6,7 @@
 package aliengame
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -27,6 +28,18 @@ var randIndex = func(length int) int {
 	return rand.Intn(length)
 }
 
+// seededRandIndex returns a randIndex func that uses a random source seeded
+// with seed so the random picks can be reproduced.
+func seededRandIndex(seed int64) func(length int) int {
+	r := rand.New(rand.NewSource(seed))
+	return func(length int) int {
+		if length <= 1 {
+			return 0
+		}
+		return r.Intn(length)
+	}
+}
+
 // World is a game world. it consist of cities, roads (directions) and aliens.
 type World struct {
 	ma sync.Mutex // protects following.
@@ -38,6 +51,64 @@ type World struct {
 	// events are used to emit game events when certain game actions happen.
 	events chan Event
 
+	// subscriptions are the subscribers of game events.
+	subscriptions map[*Subscription]bool
+
+	// collectors record the game events during Collect.
+	collectors map[*collector]bool
+
+	// randIndex used to randomly pick cities and directions.
+	randIndex func(length int) int
+
+	// iteration is the number of times that world has been resumed.
+	iteration int
+
+	// destroyedCities keeps the destroyed cities in the order of destruction.
+	destroyedCities []DestroyedCity
+
+	// killedAliens keeps the names of aliens killed by KillAlien in the order
+	// of killing.
+	killedAliens []string
+
+	// usedNames are the names of all aliens ever spawned. names are not reused
+	// since the controls and the records of the dead aliens are by names.
+	usedNames map[string]bool
+
+	// controls binds aliens to the players controlling them, by alien names.
+	controls map[string]control
+
+	// intents are the directions submitted by players to move their aliens at
+	// the next iteration, by alien names.
+	intents map[string]compass.Direction
+
+	// intentC is closed when a new intent is submitted while Resume is waiting
+	// for players.
+	intentC chan struct{}
+
+	// turnTimeout is the max duration that Resume waits for players.
+	turnTimeout time.Duration
+
+	// history keeps the changes made by iterations when it is enabled.
+	history *history
+
+	// check verifies the invariants of the world after each iteration, if it
+	// is set. world panics on violations when panicOnViolation is true.
+	check            func(mp Map, aliens []Alien) error
+	panicOnViolation bool
+
+	// err is the invariant violation that has ended the world.
+	err error
+
+	// rebuildAfter is the number of iterations to rebuild the destroyed
+	// cities after, zero means they are not rebuilt.
+	rebuildAfter int
+
+	// original is the initial map used to rebuild the destroyed cities.
+	original Map
+
+	// foundings are the new cities to found on schedule.
+	foundings []Founding
+
 	// done used to keep track of the status of the world to see if it can
 	// be resumed or not.
 	done bool
@@ -46,52 +117,102 @@ type World struct {
 // Alien is a living creature came from another world.
 type Alien struct {
 	// Name is the unique name of the alien.
-	Name string
+	Name string `json:"name"`
 
 	// CityName is the name of the city that alien is currently residing.
-	CityName string
+	CityName string `json:"cityName"`
 
 	// MoveCount is the number of times that alien has travelled to another city.
-	MoveCount int
+	MoveCount int `json:"moveCount"`
 
 	// IsTrapped indicates if alien has trapped inside a city because city does
 	// not have any neighbor city left around it.
-	IsTrapped bool
+	IsTrapped bool `json:"isTrapped"`
+}
+
+// Option is a World option.
+type Option func(*World)
+
+// WithSeed makes the world to use a random source seeded with seed. worlds
+// created with the same map, seed and actions always play the same game.
+func WithSeed(seed int64) Option {
+	return func(w *World) {
+		w.randIndex = seededRandIndex(seed)
+	}
 }
 
 // New creates a new game world by the given game map. game events sent to the
 // events channel but providing it is optional.
-func New(mp Map, events chan Event) *World {
-	return &World{
-		mp:     mp,
-		events: events,
+// world works on a copy of mp so mp can safely be reused to create more worlds.
+func New(mp Map, events chan Event, options ...Option) *World {
+	w := &World{
+		mp:          mp.Clone(),
+		events:      events,
+		randIndex:   randIndex,
+		turnTimeout: defaultTurnTimeout,
 	}
+	for _, o := range options {
+		o(w)
+	}
+	if w.rebuildAfter > 0 {
+		w.original = mp.Clone()
+	}
+	return w
 }
 
 // SpawnAlien randomly spawns new aliens on the map on different cities.
 // it can be used at any time, as much as needed to spawn more aliens on the world.
-//
-// TODO this can accept ...SpawnAlianOption options to give flexibility on which
-// city a given alien should spawn at.
+// aliens are named A1, A2 and so on by skipping the names that are used before.
+// use SpawnAlienAt to spawn an alien at a certain city.
 func (w *World) SpawnAlien(count int) {
 	w.ma.Lock()
 	defer w.ma.Unlock()
+	var names []string
+	for i := 1; len(names) < count; i++ {
+		if name := fmt.Sprintf("A%d", i); !w.usedNames[name] {
+			names = append(names, name)
+		}
+	}
 	// get an indexable list of city names so they can be randomly picked
 	// to place aliens in them.
-	var cityNames []string
-	for cityName := range w.mp {
-		cityNames = append(cityNames, cityName)
-	}
+	cityNames := w.mp.cityNames()
 	// randomly pick a city for all aliens and send them there.
-	for i := count; i >= 1; i-- {
-		x := randIndex(len(cityNames))
+	for i := len(names) - 1; i >= 0; i-- {
+		x := w.randIndex(len(cityNames))
 		city := w.mp[cityNames[x]]
-		alien := &Alien{
-			Name:     fmt.Sprintf("A%d", i),
-			CityName: city.Name,
-		}
-		w.aliens = append(w.aliens, alien)
+		w.addAlien(names[i], city.Name)
+	}
+}
+
+// SpawnAlienAt spawns a new alien named alienName at the city. it can be used at
+// any time like SpawnAlien. names of the aliens cannot be reused, even after
+// they die.
+func (w *World) SpawnAlienAt(alienName, cityName string) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if alienName == "" {
+		return fmt.Errorf("alien name cannot be empty")
+	}
+	if _, ok := w.mp[cityName]; !ok {
+		return fmt.Errorf("city %q does not exist", cityName)
+	}
+	if w.usedNames[alienName] {
+		return fmt.Errorf("alien name %q is already used", alienName)
+	}
+	w.addAlien(alienName, cityName)
+	return nil
+}
+
+// addAlien adds a new alien to the city.
+func (w *World) addAlien(alienName, cityName string) {
+	if w.usedNames == nil {
+		w.usedNames = make(map[string]bool)
 	}
+	w.usedNames[alienName] = true
+	w.aliens = append(w.aliens, &Alien{
+		Name:     alienName,
+		CityName: cityName,
+	})
 }
 
 // Resume resumes the game world for one iteration by moving aliens to the neighbor
@@ -101,20 +222,43 @@ func (w *World) SpawnAlien(count int) {
 //
 // certain events will be emited depending on the game actions.
 //
+// aliens controlled by players move to the directions submitted by their
+// players. Resume waits for all players to submit their moves or for the turn
+// timeout, aliens of the idle players move randomly. waiting stops when the
+// world is ended.
+//
 // canResume returns with false if all aliens are destroyed or all aliens have
 // reached to the max move threshold, in that case the world cannot resume anymore.
 func (w *World) Resume() (canResume bool) {
+	canResume, _ = w.resume(context.Background())
+	return canResume
+}
+
+// resume resumes the world like Resume. err is ctx's error when ctx is
+// cancelled while waiting for the players, the world is not resumed then.
+func (w *World) resume(ctx context.Context) (canResume bool, err error) {
+	if err := w.waitMoves(ctx); err != nil {
+		return true, err
+	}
+	return w.iterate(), nil
+}
+
+// iterate plays one iteration of the game.
+func (w *World) iterate() (canResume bool) {
 	w.ma.Lock()
 	defer w.ma.Unlock()
 	if w.done {
 		return false
 	}
+	w.beginDelta()
+	// record the changes after the world is ended, if it is.
+	defer w.endDelta()
 	defer func() {
 		if !canResume {
-			w.done = true
-			close(w.events)
+			w.end()
 		}
 	}()
+	w.iteration++
 	// assuming that every alien that is able to move, will move at the same time to
 	// a randomly chosen neighbor city of theirs.
 	// thus,
@@ -123,6 +267,10 @@ func (w *World) Resume() (canResume bool) {
 	// - some cities may have left with no aliens.
 	w.moveAliens()
 	w.fightAliens()
+	w.evolve()
+	if err := w.checkInvariants(); err != nil {
+		return false
+	}
 	// check if the world can be resumed again.
 	for _, alien := range w.aliens {
 		if canAlienMove(alien) {
@@ -132,6 +280,42 @@ func (w *World) Resume() (canResume bool) {
 	return false
 }
 
+// End ends the world, it cannot be resumed anymore and the events channel is
+// closed. it is safe to call End multiple times and after the world has ended by
+// itself.
+func (w *World) End() {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	w.end()
+}
+
+// Ended checks if the world has ended and cannot be resumed anymore.
+func (w *World) Ended() bool {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return w.done
+}
+
+// end marks the world as done and closes the events channel so listeners can
+// stop receiving. it is safe to call end multiple times.
+func (w *World) end() {
+	if w.done {
+		return
+	}
+	w.done = true
+	// stop waiting for the players.
+	w.wakeUp()
+	if w.events != nil {
+		close(w.events)
+		// the world can be resumed again after going back in time, but
+		// events are not sent to the closed channel.
+		w.events = nil
+	}
+	for s := range w.subscriptions {
+		w.unsubscribe(s)
+	}
+}
+
 func canAlienMove(alien *Alien) bool {
 	return alien.MoveCount < alienMaxMoveCount && !alien.IsTrapped
 }
@@ -146,10 +330,12 @@ func (w *World) moveAliens() {
 		}
 		alien.MoveCount++
 		city := w.mp[alien.CityName]
-		// list all directions/neighbors.
+		// list all directions/neighbors in the compass order.
 		var directions []compass.Direction
-		for direction := range city.Neighbors {
-			directions = append(directions, direction)
+		for _, direction := range compass.Directions {
+			if _, ok := city.Neighbors[direction]; ok {
+				directions = append(directions, direction)
+			}
 		}
 		ld := len(directions)
 		if ld == 0 {
@@ -161,16 +347,23 @@ func (w *World) moveAliens() {
 			})
 			continue
 		}
-		// randomly pick a neighbor and send alien to that city.
-		chosenDirection := directions[randIndex(ld)]
+		// send alien to the city chosen by its player, or randomly pick a
+		// neighbor.
+		chosenDirection, ok := w.intents[alien.Name]
+		if _, exists := city.Neighbors[chosenDirection]; !ok || !exists {
+			chosenDirection = directions[w.randIndex(ld)]
+		}
 		alien.CityName = city.Neighbors[chosenDirection]
 	}
+	// moves are only valid for one iteration.
+	w.intents = nil
 }
 
 // fightAliens makes the mad aliens in the same city fight which will make them
 // all dead. the city and all paths to the city also will be destroyed.
 func (w *World) fightAliens() {
-	for _, city := range w.mp {
+	for _, cityName := range w.mp.cityNames() {
+		city := w.mp[cityName]
 		// find the aliens residing on the city.
 		var aliens []*Alien
 		for _, alien := range w.aliens {
@@ -184,39 +377,135 @@ func (w *World) fightAliens() {
 		}
 		// ops! >2 aliens are in the city, they fought!
 		// now delete the aliens and city.
-		delete(w.mp, city.Name)
-		for i := len(w.aliens) - 1; i >= 0; i-- {
-			if w.aliens[i].CityName == city.Name {
-				w.aliens = append(w.aliens[:i], w.aliens[i+1:]...)
-			}
+		w.destroyCity(city, aliens, false)
+	}
+	w.updateNeighbors(false)
+}
+
+// destroyCity deletes the city and the aliens in it.
+func (w *World) destroyCity(city *City, aliens []*Alien, external bool) {
+	w.recordCity(city.Name)
+	delete(w.mp, city.Name)
+	destroyed := DestroyedCity{
+		Name:      city.Name,
+		Iteration: w.iteration,
+		External:  external,
+	}
+	for _, alien := range aliens {
+		destroyed.Aliens = append(destroyed.Aliens, alien.Name)
+	}
+	w.destroyedCities = append(w.destroyedCities, destroyed)
+	for i := len(w.aliens) - 1; i >= 0; i-- {
+		if w.aliens[i].CityName == city.Name {
+			w.aliens = append(w.aliens[:i], w.aliens[i+1:]...)
 		}
-		w.sendEvent(CityDestroyedEvent{
-			City:   city,
-			Aliens: aliens,
-		})
 	}
+	w.sendEvent(CityDestroyedEvent{
+		City:     city,
+		Aliens:   aliens,
+		External: external,
+	})
+}
+
+// updateNeighbors removes the roads to the destroyed cities and marks the
+// cities left with no neighbors.
+func (w *World) updateNeighbors(external bool) {
 	for _, city := range w.mp {
 		// remove danling neigboors (the cities that are no longer exist in the map but
 		// referenced by the existing cities).
 		for direction, neighboorCityName := range city.Neighbors {
 			if _, ok := w.mp[neighboorCityName]; !ok {
+				w.recordCity(city.Name)
 				delete(city.Neighbors, direction)
 			}
 		}
 		// send no neighboors left event, once, if a city left out with no
 		// neighboors.
 		if len(city.Neighbors) == 0 && !city.HasNoNeighbors {
+			w.recordCity(city.Name)
 			city.HasNoNeighbors = true
 			w.sendEvent(CityHasNoNeighborsEvent{
-				City: city,
+				City:     city,
+				External: external,
 			})
 		}
 	}
 }
 
-// Map gets a snapshot of current status of the city Map.
+// Map gets a snapshot of current status of the city Map. returned Map is a copy
+// and it is not affected by the later changes in the world.
 func (w *World) Map() Map {
 	w.ma.Lock()
 	defer w.ma.Unlock()
-	return w.mp
+	return w.mp.Clone()
+}
+
+// MapView gets a read-only snapshot of current status of the city Map.
+func (w *World) MapView() MapView {
+	return w.Map().View()
+}
+
+// Aliens gets a snapshot of the living aliens on the world.
+func (w *World) Aliens() []Alien {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return w.alienList()
+}
+
+// Alien gets a snapshot of the living alien by its name. ok is false when there
+// is no such alien or it is dead.
+func (w *World) Alien(name string) (alien Alien, ok bool) {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if a := w.alien(name); a != nil {
+		return *a, true
+	}
+	return Alien{}, false
+}
+
+// AliensIn gets a snapshot of the living aliens in the city.
+func (w *World) AliensIn(cityName string) []Alien {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	var aliens []Alien
+	for _, alien := range w.aliens {
+		if alien.CityName == cityName {
+			aliens = append(aliens, *alien)
+		}
+	}
+	return aliens
+}
+
+// City gets a copy of the city by its name. ok is false when there is no such
+// city or it is destroyed.
+func (w *World) City(name string) (city *City, ok bool) {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if c, ok := w.mp[name]; ok {
+		return c.Clone(), true
+	}
+	return nil, false
+}
+
+// LivingCityCount returns the number of cities that are not destroyed yet.
+func (w *World) LivingCityCount() int {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return len(w.mp)
+}
+
+// Iteration returns the number of times that world has been resumed.
+func (w *World) Iteration() int {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return w.iteration
+}
+
+// alienList copies the living aliens.
+func (w *World) alienList() []Alien {
+	aliens := make([]Alien, len(w.aliens))
+	for i, alien := range w.aliens {
+		aliens[i] = *alien
+	}
+	return aliens
 }
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/ilgooz/aliengame/x/compass"
 	"github.com/stretchr/testify/require"
 )
 
@@ -84,3 +85,109 @@ func TestRandIndex(t *testing.T) {
 		})
 	}
 }
+
+func TestWithSeed(t *testing.T) {
+	mapdef := `
+Foo north=Bar west=Baz south=Qu-ux
+Bee south=Bar
+Yee west=Bar
+`
+	play := func(seed int64) Stats {
+		mp, err := ParseMap(strings.NewReader(mapdef))
+		require.NoError(t, err)
+		require.NoError(t, CraftMap(mp))
+		world := New(mp, nil, WithSeed(seed))
+		world.SpawnAlien(4)
+		for world.Resume() {
+		}
+		return world.Stats()
+	}
+	for seed := int64(0); seed < 10; seed++ {
+		require.Equal(t, play(seed), play(seed))
+	}
+}
+
+func TestNewCopiesMap(t *testing.T) {
+	mp := Map{"Foo": &City{Name: "Foo"}}
+	world := New(mp, nil)
+	world.SpawnAlien(2)
+	for world.Resume() {
+	}
+	require.Empty(t, world.Map())
+	require.Equal(t, Map{"Foo": &City{Name: "Foo"}}, mp)
+}
+
+func TestAliens(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(2)
+	aliens := world.Aliens()
+	require.Equal(t, []Alien{
+		{Name: "A2", CityName: "Foo"},
+		{Name: "A1", CityName: "Foo"},
+	}, aliens)
+	aliens[0].CityName = "Bar"
+	require.Equal(t, "Foo", world.Aliens()[0].CityName)
+	world.Resume()
+	require.Empty(t, world.Aliens())
+}
+
+func TestQueries(t *testing.T) {
+	mp, err := ParseMap(strings.NewReader("Foo north=Bar\nBar north=Baz"))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	world := New(mp, nil)
+	require.NoError(t, world.SpawnAlienAt("X", "Foo"))
+	require.NoError(t, world.SpawnAlienAt("Y", "Baz"))
+	require.NoError(t, world.SpawnAlienAt("Z", "Baz"))
+	require.Equal(t, 0, world.Iteration())
+	require.Equal(t, 3, world.LivingCityCount())
+
+	alien, ok := world.Alien("Y")
+	require.True(t, ok)
+	require.Equal(t, Alien{Name: "Y", CityName: "Baz"}, alien)
+	_, ok = world.Alien("W")
+	require.False(t, ok)
+	require.Equal(t, []Alien{{Name: "Y", CityName: "Baz"}, {Name: "Z", CityName: "Baz"}}, world.AliensIn("Baz"))
+	require.Empty(t, world.AliensIn("Bar"))
+
+	city, ok := world.City("Foo")
+	require.True(t, ok)
+	require.Equal(t, mp["Foo"], city)
+	city.Neighbors[compass.East] = "Qux"
+	city, _ = world.City("Foo")
+	require.Equal(t, mp["Foo"], city)
+	_, ok = world.City("Qux")
+	require.False(t, ok)
+
+	// X moves to Bar and fights with Y and Z, which have moved to Bar too.
+	world.Resume()
+	require.Equal(t, 1, world.Iteration())
+	require.Equal(t, 2, world.LivingCityCount())
+	_, ok = world.City("Bar")
+	require.False(t, ok)
+	_, ok = world.Alien("X")
+	require.False(t, ok)
+}
+
+func TestEnded(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(1)
+	require.False(t, world.Ended())
+	world.End()
+	require.True(t, world.Ended())
+	require.False(t, world.Resume())
+
+	world = New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(2)
+	world.Resume()
+	require.True(t, world.Ended())
+}
+
+func TestSpawnAlienAt(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}, "Bar": &City{Name: "Bar"}}, nil)
+	require.NoError(t, world.SpawnAlienAt("X", "Bar"))
+	require.Error(t, world.SpawnAlienAt("X", "Foo"))
+	require.Error(t, world.SpawnAlienAt("Y", "Baz"))
+	require.Error(t, world.SpawnAlienAt("", "Foo"))
+	require.Equal(t, []Alien{{Name: "X", CityName: "Bar"}}, world.Aliens())
+}
@@ -0,0 +1,114 @@
+package aliengame
+
+import "github.com/ilgooz/aliengame/x/compass"
+
+// WithRebuild makes the world rebuild the destroyed cities after the given
+// number of iterations, so long games do not decay to nothing. roads of the
+// rebuilt cities are restored to the neighbors in the original map that still
+// exist and have not built another road to the same direction.
+func WithRebuild(after int) Option {
+	return func(w *World) {
+		w.rebuildAfter = after
+	}
+}
+
+// Founding is a new city that appears in the world at an iteration.
+type Founding struct {
+	// Iteration that the city is founded at.
+	Iteration int `json:"iteration"`
+
+	// City is the new city. roads to its neighbors are built when the neighbors
+	// exist, and have not built another road to the same direction.
+	City *City `json:"city"`
+}
+
+// WithFoundings makes the world found new cities on the schedule. a founding
+// is skipped when a city with the same name exists at that iteration.
+func WithFoundings(foundings ...Founding) Option {
+	return func(w *World) {
+		w.foundings = append(w.foundings, foundings...)
+	}
+}
+
+// evolve rebuilds the destroyed cities and founds the new cities that are due
+// at the current iteration.
+func (w *World) evolve() {
+	if w.rebuildAfter > 0 {
+		for _, destroyed := range w.destroyedCities {
+			if destroyed.Iteration != w.iteration-w.rebuildAfter {
+				continue
+			}
+			if city := w.buildCity(w.blueprint(destroyed.Name)); city != nil {
+				w.sendEvent(CityRebuiltEvent{City: city.Clone()})
+			}
+		}
+	}
+	for _, founding := range w.foundings {
+		if founding.Iteration != w.iteration {
+			continue
+		}
+		if city := w.buildCity(founding.City); city != nil {
+			w.sendEvent(CityFoundedEvent{City: city.Clone()})
+		}
+	}
+}
+
+// blueprint finds the original definition of the city to rebuild it.
+func (w *World) blueprint(name string) *City {
+	if city, ok := w.original[name]; ok {
+		return city
+	}
+	for _, founding := range w.foundings {
+		if founding.City.Name == name {
+			return founding.City
+		}
+	}
+	return nil
+}
+
+// buildCity adds a copy of the city to the map, with the roads to its
+// neighbors that are available. it returns nil when the city already exists.
+func (w *World) buildCity(def *City) *City {
+	if def == nil {
+		return nil
+	}
+	if _, ok := w.mp[def.Name]; ok {
+		return nil
+	}
+	w.recordCity(def.Name)
+	city := &City{
+		Name:      def.Name,
+		Neighbors: make(map[compass.Direction]string),
+	}
+	w.mp[city.Name] = city
+	for _, direction := range compass.Directions {
+		neighbor, ok := w.mp[def.Neighbors[direction]]
+		if !ok || neighbor == city {
+			continue
+		}
+		revDirection := compass.ReverseDirection(direction)
+		if _, ok := neighbor.Neighbors[revDirection]; ok {
+			continue
+		}
+		w.recordCity(neighbor.Name)
+		if neighbor.Neighbors == nil {
+			neighbor.Neighbors = make(map[compass.Direction]string)
+		}
+		city.Neighbors[direction] = neighbor.Name
+		neighbor.Neighbors[revDirection] = city.Name
+		w.untrap(neighbor)
+	}
+	city.HasNoNeighbors = len(city.Neighbors) == 0
+	return city
+}
+
+// untrap marks the city to have neighbors again, so the aliens trapped in it
+// can move.
+func (w *World) untrap(city *City) {
+	city.HasNoNeighbors = false
+	for _, alien := range w.aliens {
+		if alien.CityName == city.Name {
+			alien.IsTrapped = false
+		}
+	}
+}
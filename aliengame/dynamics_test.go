@@ -0,0 +1,84 @@
+package aliengame
+
+import (
+	"testing"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+// dynamicsMapdef and dynamicsAliens make a world where X and Y destroy Bar at
+// the first iteration, and Z keeps moving between Qux and Quux.
+const dynamicsMapdef = "Foo north=Bar\nBar north=Baz\nQux north=Quux"
+
+var dynamicsAliens = map[string]string{"X": "Foo", "Y": "Baz", "Z": "Qux"}
+
+func TestRebuild(t *testing.T) {
+	world := newTestWorld(t, dynamicsMapdef, 0, dynamicsAliens, WithRebuild(2))
+	world.Resume()
+	_, ok := world.City("Bar")
+	require.False(t, ok)
+	require.Empty(t, world.Collect(func() { require.True(t, world.Resume()) }))
+
+	events := world.Collect(func() { require.True(t, world.Resume()) })
+	require.Len(t, events, 1)
+	require.Equal(t, "Bar", events[0].(CityRebuiltEvent).City.Name)
+	bar, _ := world.City("Bar")
+	require.Equal(t, &City{Name: "Bar", Neighbors: map[compass.Direction]string{
+		compass.North: "Baz",
+		compass.South: "Foo",
+	}}, bar)
+	foo, _ := world.City("Foo")
+	require.Equal(t, &City{Name: "Foo", Neighbors: map[compass.Direction]string{compass.North: "Bar"}}, foo)
+}
+
+func TestRebuildTakenRoad(t *testing.T) {
+	world := newTestWorld(t, dynamicsMapdef, 0, dynamicsAliens, WithRebuild(1))
+	world.Resume()
+	// the road from Foo to the north is taken by Qux before Bar is rebuilt.
+	require.NoError(t, world.BuildRoad("Foo", compass.North, "Qux"))
+	require.True(t, world.Resume())
+	bar, _ := world.City("Bar")
+	require.Equal(t, map[compass.Direction]string{compass.North: "Baz"}, bar.Neighbors)
+	foo, _ := world.City("Foo")
+	require.Equal(t, map[compass.Direction]string{compass.North: "Qux"}, foo.Neighbors)
+}
+
+func TestRebuildHistory(t *testing.T) {
+	world := newTestWorld(t, dynamicsMapdef, 0, dynamicsAliens, WithRebuild(2), WithHistory(0))
+	world.Resume()
+	frame := world.Frame()
+	world.Resume()
+	world.Resume()
+	rebuilt := world.Frame()
+
+	require.NoError(t, world.JumpTo(1))
+	require.Equal(t, frame, world.Frame())
+	require.NoError(t, world.JumpTo(3))
+	require.Equal(t, rebuilt, world.Frame())
+}
+
+func TestFoundings(t *testing.T) {
+	world := newTestWorld(t, dynamicsMapdef, 0, dynamicsAliens, WithFoundings(
+		Founding{2, &City{Name: "New", Neighbors: map[compass.Direction]string{
+			compass.West: "Quux",
+			compass.East: "Bar",
+		}}},
+		// Qux already exists.
+		Founding{2, &City{Name: "Qux"}},
+	))
+	world.Resume()
+	events := world.Collect(func() { require.True(t, world.Resume()) })
+	var founded []Event
+	for _, event := range events {
+		if _, ok := event.(CityFoundedEvent); ok {
+			founded = append(founded, event)
+		}
+	}
+	require.Len(t, founded, 1)
+	require.Equal(t, "New", founded[0].(CityFoundedEvent).City.Name)
+	city, _ := world.City("New")
+	require.Equal(t, &City{Name: "New", Neighbors: map[compass.Direction]string{compass.West: "Quux"}}, city)
+	quux, _ := world.City("Quux")
+	require.Equal(t, "New", quux.Neighbors[compass.East])
+}
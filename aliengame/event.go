@@ -1,14 +1,53 @@
 package aliengame
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
 
 // Event is a game event.
 type Event interface {
 	String() string
 }
 
-// sendEvent sends a game event to the listener.
+// eventJSON is the json representation of events.
+type eventJSON struct {
+	// Type is the kind of the event.
+	Type string `json:"type"`
+
+	// Message is the human readable description of the event.
+	Message string `json:"message"`
+
+	// City is the name of the city related to the event.
+	City string `json:"city,omitempty"`
+
+	// Aliens are the names of aliens related to the event.
+	Aliens []string `json:"aliens,omitempty"`
+
+	// External is true when the event is caused by an intervention, see
+	// IsExternal.
+	External bool `json:"external,omitempty"`
+}
+
+// IsExternal checks if the event is caused by an intervention to the world
+// between iterations, like DestroyCity, rather than the game itself.
+func IsExternal(e Event) bool {
+	switch e := e.(type) {
+	case CityDestroyedEvent:
+		return e.External
+	case CityHasNoNeighborsEvent:
+		return e.External
+	case RoadCutEvent, RoadBuiltEvent, AlienKilledEvent, AlienTeleportedEvent:
+		return true
+	}
+	return false
+}
+
+// sendEvent sends a game event to the listener and subscribers.
 func (w *World) sendEvent(e Event) {
+	w.publish(e)
 	if w.events != nil {
 		w.events <- e
 	}
@@ -22,6 +61,10 @@ type CityDestroyedEvent struct {
 	// Aliens on the city that were residing just before the has
 	// destroyed.
 	Aliens []*Alien
+
+	// External is true when the city is destroyed by DestroyCity instead of
+	// a fight.
+	External bool
 }
 
 func (e CityDestroyedEvent) String() string {
@@ -29,9 +72,20 @@ func (e CityDestroyedEvent) String() string {
 	for _, alien := range e.Aliens {
 		alienNames = append(alienNames, alien.Name)
 	}
+	if e.External {
+		return fmt.Sprintf("%q has been destroyed from outside of the game: \n\t%v", e.City.Name, alienNames)
+	}
 	return fmt.Sprintf("%q has been destroyed by some mad aliens: \n\t%v", e.City.Name, alienNames)
 }
 
+func (e CityDestroyedEvent) MarshalJSON() ([]byte, error) {
+	var alienNames []string
+	for _, alien := range e.Aliens {
+		alienNames = append(alienNames, alien.Name)
+	}
+	return json.Marshal(eventJSON{"city-destroyed", e.String(), e.City.Name, alienNames, e.External})
+}
+
 // AlienTrappedEvent is emitted when an alien is trapped inside a
 // city because the city has no neighbor cities anymore.
 type AlienTrappedEvent struct {
@@ -46,12 +100,133 @@ func (e AlienTrappedEvent) String() string {
 	return fmt.Sprintf("alien %q has trapped in city %q", e.Alien.Name, e.City.Name)
 }
 
+func (e AlienTrappedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"alien-trapped", e.String(), e.City.Name, []string{e.Alien.Name}, false})
+}
+
 // CityHasNoNeighboorsEvent is emited when a city has no neighbor
 // city around it.
 type CityHasNoNeighborsEvent struct {
 	City *City
+
+	// External is true when the city has lost its neighbors because of an
+	// intervention.
+	External bool
 }
 
 func (e CityHasNoNeighborsEvent) String() string {
 	return fmt.Sprintf("city %q left with no neighbors", e.City.Name)
 }
+
+func (e CityHasNoNeighborsEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"city-has-no-neighbors", e.String(), e.City.Name, nil, e.External})
+}
+
+// RoadCutEvent is emitted when the road between two cities is removed by
+// CutRoad.
+type RoadCutEvent struct {
+	// City is the city that the road is cut from.
+	City *City
+
+	// Direction of the road from City.
+	Direction compass.Direction
+
+	// Neighbor is the city at the other end of the road.
+	Neighbor *City
+}
+
+func (e RoadCutEvent) String() string {
+	return fmt.Sprintf("road to %s of %q has been cut from %q", e.Direction, e.City.Name, e.Neighbor.Name)
+}
+
+func (e RoadCutEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"road-cut", e.String(), e.City.Name, nil, true})
+}
+
+// RoadBuiltEvent is emitted when a new road between two cities is built by
+// BuildRoad.
+type RoadBuiltEvent struct {
+	// City is the city that the road is built from.
+	City *City
+
+	// Direction of the road from City.
+	Direction compass.Direction
+
+	// Neighbor is the city at the other end of the road.
+	Neighbor *City
+}
+
+func (e RoadBuiltEvent) String() string {
+	return fmt.Sprintf("road to %s of %q has been built to %q", e.Direction, e.City.Name, e.Neighbor.Name)
+}
+
+func (e RoadBuiltEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"road-built", e.String(), e.City.Name, nil, true})
+}
+
+// AlienKilledEvent is emitted when an alien is killed by KillAlien.
+type AlienKilledEvent struct {
+	// City that the alien was in.
+	City *City
+
+	// Alien that has been killed.
+	Alien *Alien
+}
+
+func (e AlienKilledEvent) String() string {
+	return fmt.Sprintf("alien %q has been killed in city %q", e.Alien.Name, e.City.Name)
+}
+
+func (e AlienKilledEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"alien-killed", e.String(), e.City.Name, []string{e.Alien.Name}, true})
+}
+
+// AlienTeleportedEvent is emitted when an alien is moved to another city by
+// TeleportAlien.
+type AlienTeleportedEvent struct {
+	// From is the city that the alien has left.
+	From *City
+
+	// To is the city that the alien has arrived.
+	To *City
+
+	// Alien that has been teleported.
+	Alien *Alien
+}
+
+func (e AlienTeleportedEvent) String() string {
+	return fmt.Sprintf("alien %q has been teleported from %q to %q", e.Alien.Name, e.From.Name, e.To.Name)
+}
+
+func (e AlienTeleportedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"alien-teleported", e.String(), e.To.Name, []string{e.Alien.Name}, true})
+}
+
+// CityRebuiltEvent is emitted when a destroyed city is rebuilt, see
+// WithRebuild.
+type CityRebuiltEvent struct {
+	// City that has been rebuilt, with its restored roads.
+	City *City
+}
+
+func (e CityRebuiltEvent) String() string {
+	return fmt.Sprintf("city %q has been rebuilt", e.City.Name)
+}
+
+func (e CityRebuiltEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"city-rebuilt", e.String(), e.City.Name, nil, false})
+}
+
+// CityFoundedEvent is emitted when a new city is founded, see WithFoundings.
+type CityFoundedEvent struct {
+	// City that has been founded, with its roads.
+	City *City
+}
+
+func (e CityFoundedEvent) String() string {
+	return fmt.Sprintf("city %q has been founded", e.City.Name)
+}
+
+func (e CityFoundedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventJSON{"city-founded", e.String(), e.City.Name, nil, false})
+}
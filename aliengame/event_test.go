@@ -1,8 +1,10 @@
 package aliengame
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/ilgooz/aliengame/x/compass"
 	"github.com/stretchr/testify/require"
 )
 
@@ -10,6 +12,12 @@ import (
 var _ Event = (*CityDestroyedEvent)(nil)
 var _ Event = (*CityHasNoNeighborsEvent)(nil)
 var _ Event = (*AlienTrappedEvent)(nil)
+var _ Event = (*RoadCutEvent)(nil)
+var _ Event = (*RoadBuiltEvent)(nil)
+var _ Event = (*AlienKilledEvent)(nil)
+var _ Event = (*AlienTeleportedEvent)(nil)
+var _ Event = (*CityRebuiltEvent)(nil)
+var _ Event = (*CityFoundedEvent)(nil)
 
 func TestCityDestroyedEvent(t *testing.T) {
 	require.Equal(t, "\"1\" has been destroyed by some mad aliens: \n\t[2 3]", CityDestroyedEvent{
@@ -45,3 +53,59 @@ func TestAlienTrappedEvent(t *testing.T) {
 		},
 	}.String())
 }
+
+func TestEventJSON(t *testing.T) {
+	city := &City{Name: "1"}
+	alien := &Alien{Name: "2"}
+	neighbor := &City{Name: "3"}
+	cases := []struct {
+		event Event
+		json  string
+	}{
+		{
+			CityDestroyedEvent{City: city, Aliens: []*Alien{alien}},
+			`{"type":"city-destroyed","message":"\"1\" has been destroyed by some mad aliens: \n\t[2]","city":"1","aliens":["2"]}`,
+		},
+		{
+			AlienTrappedEvent{City: city, Alien: alien},
+			`{"type":"alien-trapped","message":"alien \"2\" has trapped in city \"1\"","city":"1","aliens":["2"]}`,
+		},
+		{
+			CityHasNoNeighborsEvent{City: city},
+			`{"type":"city-has-no-neighbors","message":"city \"1\" left with no neighbors","city":"1"}`,
+		},
+		{
+			CityDestroyedEvent{City: city, Aliens: []*Alien{alien}, External: true},
+			`{"type":"city-destroyed","message":"\"1\" has been destroyed from outside of the game: \n\t[2]","city":"1","aliens":["2"],"external":true}`,
+		},
+		{
+			RoadCutEvent{City: city, Direction: compass.North, Neighbor: neighbor},
+			`{"type":"road-cut","message":"road to North of \"1\" has been cut from \"3\"","city":"1","external":true}`,
+		},
+		{
+			RoadBuiltEvent{City: city, Direction: compass.North, Neighbor: neighbor},
+			`{"type":"road-built","message":"road to North of \"1\" has been built to \"3\"","city":"1","external":true}`,
+		},
+		{
+			AlienKilledEvent{City: city, Alien: alien},
+			`{"type":"alien-killed","message":"alien \"2\" has been killed in city \"1\"","city":"1","aliens":["2"],"external":true}`,
+		},
+		{
+			AlienTeleportedEvent{From: neighbor, To: city, Alien: alien},
+			`{"type":"alien-teleported","message":"alien \"2\" has been teleported from \"3\" to \"1\"","city":"1","aliens":["2"],"external":true}`,
+		},
+		{
+			CityRebuiltEvent{City: city},
+			`{"type":"city-rebuilt","message":"city \"1\" has been rebuilt","city":"1"}`,
+		},
+		{
+			CityFoundedEvent{City: city},
+			`{"type":"city-founded","message":"city \"1\" has been founded","city":"1"}`,
+		},
+	}
+	for _, tt := range cases {
+		data, err := json.Marshal(tt.event)
+		require.NoError(t, err)
+		require.Equal(t, tt.json, string(data))
+	}
+}
@@ -0,0 +1,93 @@
+package aliengame
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// FormatMap rewrites the map defination read from r to w in the canonical
+// format. cities and directions are sorted, directions are lowercased, names
+// are quoted only when needed and the links that CraftMap would infer from the
+// links of the neighbors are removed. every city defined in r keeps its line,
+// so the names prefixed by regions of ParseMapFS do not change.
+//
+// comments that do not belong to a city are moved to the top, followed by the
+// include directives. error is not nil when a city is defined more than once.
+func FormatMap(r io.Reader, w io.Writer) error {
+	var (
+		mp       = make(Map)
+		lines    = make(map[string]int)
+		header   [][]string
+		includes []include
+	)
+	err := parseMap(r, func(city *City, lineNumber int) error {
+		if prev, ok := lines[city.Name]; ok {
+			return fmt.Errorf("city %q found at line '%d' is already defined at line '%d'", city.Name, lineNumber, prev)
+		}
+		lines[city.Name] = lineNumber
+		mp[city.Name] = city
+		return nil
+	}, func(inc include) error {
+		includes = append(includes, inc)
+		return nil
+	}, func(comments []string) {
+		header = append(header, comments)
+	})
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, comments := range header {
+		for _, comment := range comments {
+			fmt.Fprintf(bw, "#%s\n", comment)
+		}
+		bw.WriteString("\n")
+	}
+	for _, inc := range includes {
+		fmt.Fprintf(bw, "include %s", inc.path)
+		if inc.region != "" {
+			fmt.Fprintf(bw, " as %s", inc.region)
+		}
+		bw.WriteString("\n")
+	}
+	if len(includes) > 0 && len(mp) > 0 {
+		bw.WriteString("\n")
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return PrintMap(w, mp.withoutBackLinks())
+}
+
+// withoutBackLinks returns a copy of the map where one of the two links
+// between the neighbors that link to each other is removed, since CraftMap adds
+// it again. the link of the alphabetically greater city is removed unless it is
+// the only link of that city.
+func (mp Map) withoutBackLinks() Map {
+	compact := mp.Clone()
+	for _, name := range compact.cityNames() {
+		city := compact[name]
+		for _, direction := range compass.Directions {
+			neighborName, ok := city.Neighbors[direction]
+			if !ok || neighborName <= name {
+				continue
+			}
+			neighbor, ok := compact[neighborName]
+			revDirection := compass.ReverseDirection(direction)
+			if !ok || neighbor.Neighbors[revDirection] != name {
+				continue
+			}
+			switch {
+			case len(neighbor.Neighbors) > 1:
+				delete(neighbor.Neighbors, revDirection)
+			case len(city.Neighbors) > 1:
+				delete(city.Neighbors, direction)
+			}
+		}
+	}
+	return compact
+}
@@ -0,0 +1,147 @@
+package aliengame
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMap(t *testing.T) {
+	mapdef := `# world map
+
+Foo North=Bar  west=Baz south="Qu-ux"
+# the bar
+Bar SOUTH=Foo west=Bee
+include europe.aliengame   as Europe
+Yee west=Bar # trailing
+Bee east=Bar
+Qu-ux north=Foo # trailing
+
+# end of file
+`
+	var buf bytes.Buffer
+	require.NoError(t, FormatMap(strings.NewReader(mapdef), &buf))
+	require.Equal(t, `# world map
+
+# end of file
+
+include europe.aliengame as Europe
+
+# the bar
+Bar south=Foo
+Bee east=Bar
+Foo west=Baz
+Qu-ux north=Foo # trailing
+Yee west=Bar # trailing
+`, buf.String())
+
+	_, err := ParseMap(strings.NewReader(strings.Replace(buf.String(), "include", "#", 1)))
+	require.NoError(t, err)
+
+	require.Error(t, FormatMap(strings.NewReader("Foo up=Bar"), &buf))
+	require.EqualError(t, FormatMap(strings.NewReader("Foo north=Bar\nBar south=Foo\nFoo east=Baz"), &buf),
+		`city "Foo" found at line '3' is already defined at line '1'`)
+}
+
+func TestFormatMapRegion(t *testing.T) {
+	mapdef := "Paris east=Berlin\nBerlin west=Paris north=Oslo\nOslo south=Berlin\n"
+	var formatted bytes.Buffer
+	require.NoError(t, FormatMap(strings.NewReader(mapdef), &formatted))
+	require.Equal(t, "Berlin west=Paris\nOslo south=Berlin\nParis east=Berlin\n", formatted.String())
+
+	// included cities are prefixed with the region the same way.
+	include := func(mapdef []byte) Map {
+		mp, err := ParseMapFS(fstest.MapFS{
+			"a.aliengame": {Data: []byte("include b.aliengame as Europe\n")},
+			"b.aliengame": {Data: mapdef},
+		}, "a.aliengame")
+		require.NoError(t, err)
+		require.NoError(t, CraftMap(mp))
+		return mp
+	}
+	require.Equal(t, neighborsOf(include([]byte(mapdef))), neighborsOf(include(formatted.Bytes())))
+}
+
+func FuzzFormatMap(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"Foo north=Bar west=Baz south=Qu-ux\nBee south=Bar\nYee west=Bar\n",
+		"# header\n\n\"New York\" north=\"St. Louis\" # trailing\n",
+		"include a.aliengame as A\nFoo north=Foo\n",
+		"Foo north=Bar\nBar south=Foo\n# comment\nBaz east=Foo\n",
+		"Foo north=Bar\r\nFoo north=Baz\r\n",
+		"Paris east=Berlin\nBerlin west=Paris\n",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, mapdef string) {
+		var formatted bytes.Buffer
+		if err := FormatMap(strings.NewReader(mapdef), &formatted); err != nil {
+			return
+		}
+		var again bytes.Buffer
+		require.NoError(t, FormatMap(bytes.NewReader(formatted.Bytes()), &again))
+		require.Equal(t, formatted.String(), again.String())
+
+		// formatting does not change the map that CraftMap makes, which is only
+		// known for maps without conflicting links.
+		original := parseFormatted(t, []byte(mapdef))
+		if !hasConsistentLinks(original) || len(original) == 0 {
+			return
+		}
+		crafted := parseFormatted(t, formatted.Bytes())
+		require.NoError(t, CraftMap(original))
+		require.NoError(t, CraftMap(crafted))
+		require.Equal(t, neighborsOf(original), neighborsOf(crafted))
+	})
+}
+
+// parseFormatted parses the map defination by skipping the include directives.
+func parseFormatted(t *testing.T, mapdef []byte) Map {
+	mp := make(Map)
+	require.NoError(t, parseMap(bytes.NewReader(mapdef), func(city *City, _ int) error {
+		mp[city.Name] = city
+		return nil
+	}, func(include) error { return nil }, nil))
+	return mp
+}
+
+// hasConsistentLinks checks if no two links of the map, or the links that
+// CraftMap adds back for them, lead to different cities from the same direction
+// of a city.
+func hasConsistentLinks(mp Map) bool {
+	type slot struct {
+		city      string
+		direction compass.Direction
+	}
+	links := make(map[slot]string)
+	link := func(city string, direction compass.Direction, neighborName string) bool {
+		if prev, ok := links[slot{city, direction}]; ok && prev != neighborName {
+			return false
+		}
+		links[slot{city, direction}] = neighborName
+		return true
+	}
+	for name, city := range mp {
+		for direction, neighborName := range city.Neighbors {
+			if !link(name, direction, neighborName) ||
+				!link(neighborName, compass.ReverseDirection(direction), name) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// neighborsOf returns the neighbors of the cities in the map.
+func neighborsOf(mp Map) map[string]map[compass.Direction]string {
+	neighbors := make(map[string]map[compass.Direction]string, len(mp))
+	for name, city := range mp {
+		neighbors[name] = city.Neighbors
+	}
+	return neighbors
+}
@@ -0,0 +1,264 @@
+package aliengame
+
+import (
+	"sort"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// Road is a direct path between two cities. A is always alphabetically
+// smaller than B.
+type Road struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+// newRoad creates a road between cities a and b.
+func newRoad(a, b string) Road {
+	if b < a {
+		a, b = b, a
+	}
+	return Road{a, b}
+}
+
+// adjacency returns the neighbors of each city in the map. roads are treated as
+// bidirectional, and neighbors that do not exist in the map are ignored.
+// neighbor lists are sorted so the graph algorithms are deterministic.
+func (mp Map) adjacency() map[string][]string {
+	links := make(map[string]map[string]bool, len(mp))
+	for name := range mp {
+		links[name] = make(map[string]bool)
+	}
+	for name, city := range mp {
+		for _, direction := range compass.Directions {
+			neighborName, ok := city.Neighbors[direction]
+			if !ok || neighborName == name {
+				continue
+			}
+			if _, ok := mp[neighborName]; !ok {
+				continue
+			}
+			links[name][neighborName] = true
+			links[neighborName][name] = true
+		}
+	}
+	adj := make(map[string][]string, len(mp))
+	for name, neighbors := range links {
+		for neighborName := range neighbors {
+			adj[name] = append(adj[name], neighborName)
+		}
+		sort.Strings(adj[name])
+	}
+	return adj
+}
+
+// roadCounts returns the number of roads between each pair of neighbor cities.
+// a pair can be linked by parallel roads in different directions.
+func (mp Map) roadCounts() map[Road]int {
+	links := make(map[[2]string]int)
+	for name, city := range mp {
+		for _, neighborName := range city.Neighbors {
+			if _, ok := mp[neighborName]; ok && neighborName != name {
+				links[[2]string{name, neighborName}]++
+			}
+		}
+	}
+	// a road is counted from both of its ends, and one-way links are roads
+	// too.
+	counts := make(map[Road]int)
+	for link, count := range links {
+		road := newRoad(link[0], link[1])
+		if count > counts[road] {
+			counts[road] = count
+		}
+	}
+	return counts
+}
+
+// Components returns the groups of cities that are connected to each other by
+// roads. city names in each component are sorted, components are sorted by
+// their size in descending order, and then by their first city name.
+func (mp Map) Components() [][]string {
+	adj := mp.adjacency()
+	visited := make(map[string]bool)
+	var components [][]string
+	for _, cityName := range mp.cityNames() {
+		if visited[cityName] {
+			continue
+		}
+		// walk through all reachable cities.
+		var component []string
+		queue := []string{cityName}
+		visited[cityName] = true
+		for len(queue) > 0 {
+			name := queue[0]
+			queue = queue[1:]
+			component = append(component, name)
+			for _, neighborName := range adj[name] {
+				if !visited[neighborName] {
+					visited[neighborName] = true
+					queue = append(queue, neighborName)
+				}
+			}
+		}
+		sort.Strings(component)
+		components = append(components, component)
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return len(components[i]) > len(components[j])
+	})
+	return components
+}
+
+// ShortestPath finds one of the shortest paths between from and to cities.
+// path includes both from and to cities. ok is false when there is no path
+// between the cities or one of them does not exist.
+func (mp Map) ShortestPath(from, to string) (path []string, ok bool) {
+	if _, ok := mp[from]; !ok {
+		return nil, false
+	}
+	if _, ok := mp[to]; !ok {
+		return nil, false
+	}
+	adj := mp.adjacency()
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if name == to {
+			for ; name != from; name = prev[name] {
+				path = append(path, name)
+			}
+			path = append(path, from)
+			// reverse the path to start from the from city.
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, true
+		}
+		for _, neighborName := range adj[name] {
+			if _, ok := prev[neighborName]; !ok {
+				prev[neighborName] = name
+				queue = append(queue, neighborName)
+			}
+		}
+	}
+	return nil, false
+}
+
+// DegreeDistribution returns the number of cities for each degree. degree of a
+// city is the number of distinct neighbor cities it has.
+func (mp Map) DegreeDistribution() map[int]int {
+	dist := make(map[int]int)
+	for _, neighbors := range mp.adjacency() {
+		dist[len(neighbors)]++
+	}
+	return dist
+}
+
+// ArticulationPoints returns the sorted names of cities that split their
+// component into more pieces when they are destroyed.
+func (mp Map) ArticulationPoints() []string {
+	points, _ := mp.cutPoints()
+	return points
+}
+
+// Bridges returns the roads that split their component into more pieces when
+// they are removed. roads are sorted.
+func (mp Map) Bridges() []Road {
+	_, bridges := mp.cutPoints()
+	return bridges
+}
+
+// cutPoints finds articulation points and bridges with Tarjan's algorithm.
+func (mp Map) cutPoints() (points []string, bridges []Road) {
+	adj := mp.adjacency()
+	roads := mp.roadCounts()
+	var (
+		timer    int
+		disc     = make(map[string]int) // discovery times.
+		low      = make(map[string]int) // lowest discovery time reachable.
+		isPoint  = make(map[string]bool)
+		traverse func(name, parent string)
+	)
+	traverse = func(name, parent string) {
+		timer++
+		disc[name], low[name] = timer, timer
+		children := 0
+		for _, neighborName := range adj[name] {
+			// skip the road to the parent, parallel roads to it are back
+			// edges.
+			if neighborName == parent && roads[newRoad(name, parent)] == 1 {
+				continue
+			}
+			if _, ok := disc[neighborName]; ok {
+				if disc[neighborName] < low[name] {
+					low[name] = disc[neighborName]
+				}
+				continue
+			}
+			children++
+			traverse(neighborName, name)
+			if low[neighborName] < low[name] {
+				low[name] = low[neighborName]
+			}
+			if parent != "" && low[neighborName] >= disc[name] {
+				isPoint[name] = true
+			}
+			if low[neighborName] > disc[name] {
+				bridges = append(bridges, newRoad(name, neighborName))
+			}
+		}
+		if parent == "" && children > 1 {
+			isPoint[name] = true
+		}
+	}
+	for _, cityName := range mp.cityNames() {
+		if _, ok := disc[cityName]; !ok {
+			traverse(cityName, "")
+		}
+	}
+	for name := range isPoint {
+		points = append(points, name)
+	}
+	sort.Strings(points)
+	sortRoads(bridges)
+	return points, bridges
+}
+
+// sortRoads sorts roads by their A and then B cities.
+func sortRoads(roads []Road) {
+	sort.Slice(roads, func(i, j int) bool {
+		if roads[i].A != roads[j].A {
+			return roads[i].A < roads[j].A
+		}
+		return roads[i].B < roads[j].B
+	})
+}
+
+// Diameter returns the longest of the shortest path lengths between any two
+// connected cities, in number of roads.
+func (mp Map) Diameter() int {
+	adj := mp.adjacency()
+	var diameter int
+	for name := range adj {
+		// find the farthest city from this city.
+		dist := map[string]int{name: 0}
+		queue := []string{name}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if dist[current] > diameter {
+				diameter = dist[current]
+			}
+			for _, neighborName := range adj[current] {
+				if _, ok := dist[neighborName]; !ok {
+					dist[neighborName] = dist[current] + 1
+					queue = append(queue, neighborName)
+				}
+			}
+		}
+	}
+	return diameter
+}
@@ -0,0 +1,80 @@
+package aliengame
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// graphMapdef is a map with two components:
+//
+//	Bar - Foo - Baz     Zed - Qux
+//	       |     |
+//	      Bee - Yee - Lee
+const graphMapdef = `
+Foo west=Bar east=Baz south=Bee
+Bee east=Yee
+Yee north=Baz east=Lee
+Zed east=Qux
+`
+
+func newGraphMap(t *testing.T) Map {
+	mp, err := ParseMap(strings.NewReader(graphMapdef))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	return mp
+}
+
+func TestComponents(t *testing.T) {
+	mp := newGraphMap(t)
+	require.Equal(t, [][]string{
+		{"Bar", "Baz", "Bee", "Foo", "Lee", "Yee"},
+		{"Qux", "Zed"},
+	}, mp.Components())
+	require.Nil(t, Map{}.Components())
+}
+
+func TestShortestPath(t *testing.T) {
+	mp := newGraphMap(t)
+	path, ok := mp.ShortestPath("Bar", "Lee")
+	require.True(t, ok)
+	require.Len(t, path, 5)
+	require.Equal(t, "Bar", path[0])
+	require.Equal(t, "Foo", path[1])
+	require.Equal(t, "Lee", path[4])
+
+	path, ok = mp.ShortestPath("Foo", "Foo")
+	require.True(t, ok)
+	require.Equal(t, []string{"Foo"}, path)
+
+	_, ok = mp.ShortestPath("Foo", "Zed")
+	require.False(t, ok)
+	_, ok = mp.ShortestPath("Foo", "Nope")
+	require.False(t, ok)
+}
+
+func TestDegreeDistribution(t *testing.T) {
+	require.Equal(t, map[int]int{1: 4, 2: 2, 3: 2}, newGraphMap(t).DegreeDistribution())
+}
+
+func TestArticulationPointsAndBridges(t *testing.T) {
+	mp := newGraphMap(t)
+	require.Equal(t, []string{"Foo", "Yee"}, mp.ArticulationPoints())
+	require.Equal(t, []Road{
+		{"Bar", "Foo"},
+		{"Lee", "Yee"},
+		{"Qux", "Zed"},
+	}, mp.Bridges())
+	// parallel roads between the same cities are not bridges.
+	mp, err := ParseMap(strings.NewReader("Foo north=Bar east=Bar\nBar north=Baz"))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	require.Equal(t, []string{"Bar"}, mp.ArticulationPoints())
+	require.Equal(t, []Road{{"Bar", "Baz"}}, mp.Bridges())
+}
+
+func TestDiameter(t *testing.T) {
+	require.Equal(t, 4, newGraphMap(t).Diameter())
+	require.Equal(t, 0, Map{"Foo": &City{Name: "Foo"}}.Diameter())
+}
@@ -0,0 +1,232 @@
+package aliengame
+
+import "fmt"
+
+// WithHistory makes the world keep the changes made by the last size
+// iterations, so it can go back in time with StepBack and JumpTo. all
+// iterations are kept when size is zero.
+//
+// randomness is not rewound, resuming the world after going back may play a
+// different game than before.
+func WithHistory(size int) Option {
+	return func(w *World) {
+		w.history = &history{size: size}
+	}
+}
+
+// history is a ring of per-iteration changes in the world state.
+type history struct {
+	// size is the max number of deltas kept, zero means no limit.
+	size int
+
+	// base is the state of the world before the first delta.
+	base historyBase
+
+	// deltas are the changes made by each iteration after base in order.
+	deltas []*delta
+
+	// position is the number of deltas applied to base to get the current
+	// state of the world. it is less than the number of deltas after going
+	// back in time.
+	position int
+
+	// current is the delta of the ongoing iteration.
+	current *delta
+}
+
+// historyBase is the state of the world that deltas are applied on.
+type historyBase struct {
+	mp        Map
+	iteration int
+
+	// destroyedCount is the number of destroyed cities.
+	destroyedCount int
+
+	// killedCount is the number of aliens killed by KillAlien.
+	killedCount int
+}
+
+// delta is the changes in the world state made by an iteration.
+type delta struct {
+	// cities are the states of the changed cities before and after the
+	// iteration, by city names.
+	cities map[string]cityChange
+
+	// aliensBefore and aliensAfter are the living aliens before and after the
+	// iteration.
+	aliensBefore, aliensAfter []Alien
+
+	// destroyedCount is the number of destroyed cities before the iteration.
+	destroyedCount int
+
+	// destroyed are the cities destroyed in the iteration.
+	destroyed []DestroyedCity
+
+	// killedCount is the number of aliens killed by KillAlien before the
+	// delta.
+	killedCount int
+
+	// killed are the aliens killed by KillAlien since the previous iteration.
+	killed []string
+
+	// done is true when the world has ended by the iteration.
+	done bool
+}
+
+// cityChange is the state of a city before and after an iteration. before is
+// nil when the city has been built, and after is nil when it has been
+// destroyed.
+type cityChange struct {
+	before, after *City
+}
+
+// apply applies the changes of the delta to mp.
+func (d *delta) apply(mp Map) {
+	for name, change := range d.cities {
+		if change.after == nil {
+			delete(mp, name)
+			continue
+		}
+		mp[name] = change.after.Clone()
+	}
+}
+
+// History returns the range of iterations that the world can go back and forth
+// to with StepBack and JumpTo. first and last are the current iteration when
+// history is not enabled or no iterations have been made yet.
+func (w *World) History() (first, last int) {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	h := w.history
+	if h == nil || len(h.deltas) == 0 {
+		return w.iteration, w.iteration
+	}
+	return h.base.iteration, h.base.iteration + len(h.deltas)
+}
+
+// StepBack restores the state of the world before the last iteration.
+func (w *World) StepBack() error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return w.jumpTo(w.iteration - 1)
+}
+
+// JumpTo restores the state of the world right after the iteration by
+// rebuilding it from the oldest kept state and the changes of each iteration.
+// it can jump back and also forth to the iterations undone by StepBack and
+// JumpTo until the world is resumed again.
+// the world can be resumed after jumping to an iteration before it has ended,
+// but the events channel given to New is not reopened.
+func (w *World) JumpTo(iteration int) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return w.jumpTo(iteration)
+}
+
+func (w *World) jumpTo(iteration int) error {
+	h := w.history
+	if h == nil {
+		return fmt.Errorf("history is not enabled")
+	}
+	first, last := h.base.iteration, h.base.iteration+len(h.deltas)
+	if len(h.deltas) == 0 || iteration < first || iteration > last {
+		return fmt.Errorf("iteration %d is not in the history", iteration)
+	}
+	position := iteration - first
+	mp := h.base.mp.Clone()
+	destroyed := w.destroyedCities[:h.base.destroyedCount:h.base.destroyedCount]
+	killed := w.killedAliens[:h.base.killedCount:h.base.killedCount]
+	for _, d := range h.deltas[:position] {
+		d.apply(mp)
+		destroyed = append(destroyed, d.destroyed...)
+		killed = append(killed, d.killed...)
+	}
+	aliens, done := h.deltas[0].aliensBefore, false
+	if position > 0 {
+		aliens, done = h.deltas[position-1].aliensAfter, h.deltas[position-1].done
+	}
+	w.mp = mp
+	w.aliens = make([]*Alien, len(aliens))
+	for i, alien := range aliens {
+		alien := alien
+		w.aliens[i] = &alien
+	}
+	w.destroyedCities = destroyed
+	w.killedAliens = killed
+	w.iteration = iteration
+	w.done = done
+	w.intents = nil
+	h.position = position
+	// the interventions made after the last iteration are undone too.
+	h.current = nil
+	return nil
+}
+
+// beginDelta starts recording the changes of a new iteration.
+func (w *World) beginDelta() {
+	h := w.history
+	// the interventions made after the last iteration have already started
+	// the delta of the next iteration.
+	if h == nil || h.current != nil {
+		return
+	}
+	// the iterations undone are overwritten by the new one.
+	h.deltas = h.deltas[:h.position]
+	if len(h.deltas) == 0 {
+		h.base = historyBase{w.mp.Clone(), w.iteration, len(w.destroyedCities), len(w.killedAliens)}
+	}
+	h.current = &delta{
+		cities:         make(map[string]cityChange),
+		aliensBefore:   w.alienList(),
+		destroyedCount: len(w.destroyedCities),
+		killedCount:    len(w.killedAliens),
+	}
+}
+
+// recordCity records the state of the city before it is changed by the
+// ongoing iteration, or by the interventions before it.
+func (w *World) recordCity(name string) {
+	if w.history == nil || w.history.current == nil {
+		return
+	}
+	if _, ok := w.history.current.cities[name]; ok {
+		return
+	}
+	var change cityChange
+	if city, ok := w.mp[name]; ok {
+		change.before = city.Clone()
+	}
+	w.history.current.cities[name] = change
+}
+
+// endDelta completes recording the changes of the ongoing iteration.
+func (w *World) endDelta() {
+	h := w.history
+	if h == nil {
+		return
+	}
+	d := h.current
+	h.current = nil
+	for name, change := range d.cities {
+		if city, ok := w.mp[name]; ok {
+			change.after = city.Clone()
+			d.cities[name] = change
+		}
+	}
+	d.aliensAfter = w.alienList()
+	d.destroyed = append(d.destroyed, w.destroyedCities[d.destroyedCount:]...)
+	d.killed = append(d.killed, w.killedAliens[d.killedCount:]...)
+	d.done = w.done
+	h.deltas = append(h.deltas, d)
+	h.position++
+	// move the base forward to drop the oldest delta.
+	if h.size > 0 && len(h.deltas) > h.size {
+		oldest := h.deltas[0]
+		oldest.apply(h.base.mp)
+		h.base.iteration++
+		h.base.destroyedCount += len(oldest.destroyed)
+		h.base.killedCount += len(oldest.killed)
+		h.deltas = h.deltas[1:]
+		h.position--
+	}
+}
@@ -0,0 +1,97 @@
+package aliengame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newHistoryWorld creates a seeded world with history on the test map, and
+// plays it till the end while recording the frames after each iteration.
+func newHistoryWorld(t *testing.T, size int, events chan Event) (*World, []Frame) {
+	world := newTestWorld(t, `
+Foo north=Bar west=Baz south=Qu-ux
+Bee south=Bar
+Yee west=Bar
+`, 4, nil, WithSeed(3), WithHistory(size), withEvents(events))
+	frames := []Frame{world.Frame()}
+	for world.Resume() {
+		frames = append(frames, world.Frame())
+	}
+	frames = append(frames, world.Frame())
+	return world, frames
+}
+
+func TestJumpTo(t *testing.T) {
+	world, frames := newHistoryWorld(t, 0, nil)
+	stats := world.Stats()
+	require.True(t, world.Ended())
+	first, last := world.History()
+	require.Equal(t, 0, first)
+	require.Equal(t, len(frames)-1, last)
+
+	for i := len(frames) - 1; i >= 0; i-- {
+		require.NoError(t, world.JumpTo(i))
+		require.Equal(t, frames[i], world.Frame())
+		var destroyed []DestroyedCity
+		for _, city := range stats.DestroyedCities {
+			if city.Iteration <= i {
+				destroyed = append(destroyed, city)
+			}
+		}
+		require.Equal(t, destroyed, world.Stats().DestroyedCities)
+	}
+	require.False(t, world.Ended())
+
+	// jump forth to the end again.
+	require.NoError(t, world.JumpTo(last))
+	require.Equal(t, stats, world.Stats())
+	require.True(t, world.Ended())
+
+	require.Error(t, world.JumpTo(-1))
+	require.Error(t, world.JumpTo(last+1))
+}
+
+func TestStepBack(t *testing.T) {
+	events := make(chan Event, 1000)
+	world, frames := newHistoryWorld(t, 0, events)
+	_, last := world.History()
+	require.NoError(t, world.StepBack())
+	require.Equal(t, frames[last-1], world.Frame())
+	require.NoError(t, world.StepBack())
+	require.Equal(t, frames[last-2], world.Frame())
+
+	// resuming overwrites the undone iterations. events channel is closed
+	// and not used anymore.
+	world.Resume()
+	first, newLast := world.History()
+	require.Equal(t, 0, first)
+	require.Equal(t, last-1, newLast)
+	require.Error(t, world.JumpTo(last))
+
+	require.NoError(t, world.JumpTo(0))
+	require.Error(t, world.StepBack())
+}
+
+func TestHistorySize(t *testing.T) {
+	world, frames := newHistoryWorld(t, 3, nil)
+	first, last := world.History()
+	require.Equal(t, len(frames)-1, last)
+	require.Equal(t, last-3, first)
+	require.Error(t, world.JumpTo(first-1))
+	for i := first; i <= last; i++ {
+		require.NoError(t, world.JumpTo(i))
+		require.Equal(t, frames[i], world.Frame())
+	}
+}
+
+func TestHistoryNotEnabled(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(1)
+	world.Resume()
+	first, last := world.History()
+	require.Equal(t, 1, first)
+	require.Equal(t, 1, last)
+	require.Error(t, world.StepBack())
+	require.Error(t, world.JumpTo(0))
+}
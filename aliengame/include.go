@@ -0,0 +1,203 @@
+package aliengame
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// includeKeyword starts the include directives.
+var includeKeyword = []byte("include")
+
+// regionRe matches the valid region names.
+var regionRe = regexp.MustCompile(`^[\p{L}\d_-]+$`)
+
+// ParseMapFS parses the map defination in the file at name from fsys, together
+// with the files included by it. included cities are not crafted, so the links
+// between the cities of different files are resolved by CraftMap.
+//
+// a file includes another one with a line like:
+//
+//	include continents/europe.aliengame
+//
+// where the path is relative to the including file. an included file can be
+// placed in a region to prefix the names of the cities defined in it, and the
+// links to them, with the region name and a dash:
+//
+//	include continents/europe.aliengame as Europe
+//
+// cities that are only linked to without their own lines in the included file
+// are not prefixed, so they can be the cities of other files.
+//
+// error is a *MapFileError that points to the file and line of the problem
+// when a file cannot be parsed, files include each other in a cycle or a city
+// is defined more than once.
+func ParseMapFS(fsys fs.FS, name string) (Map, error) {
+	p := &fsParser{fsys: fsys}
+	defs, err := p.parseFile(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	mp := make(Map)
+	positions := make(map[string]cityDef)
+	for _, def := range defs {
+		if prev, ok := positions[def.city.Name]; ok {
+			return nil, &MapFileError{def.path, def.lineNumber,
+				fmt.Errorf("city %q is already defined at %s:%d", def.city.Name, prev.path, prev.lineNumber)}
+		}
+		positions[def.city.Name] = def
+		mp[def.city.Name] = def.city
+	}
+	return mp, nil
+}
+
+// MapFileError is returned when a map file or a file included by it cannot be
+// parsed.
+type MapFileError struct {
+	// Path of the file where error is found.
+	Path string
+
+	// LineNumber where error is found, zero when Err already tells it.
+	LineNumber int
+
+	Err error
+}
+
+func (e *MapFileError) Error() string {
+	if e.LineNumber == 0 {
+		return fmt.Sprintf("%s: %s", e.Path, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.Path, e.LineNumber, e.Err)
+}
+
+func (e *MapFileError) Unwrap() error {
+	return e.Err
+}
+
+// fsParser parses map files from a file system.
+type fsParser struct {
+	fsys fs.FS
+}
+
+// cityDef is a city with the position of its defination.
+type cityDef struct {
+	city       *City
+	path       string
+	lineNumber int
+}
+
+// parseFile parses the map file at name and the files included by it. stack
+// is the chain of the files that include name.
+func (p *fsParser) parseFile(name string, stack []string) ([]cityDef, error) {
+	f, err := p.fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var defs []cityDef
+	err = parseMap(f, func(city *City, lineNumber int) error {
+		defs = append(defs, cityDef{city, name, lineNumber})
+		return nil
+	}, func(inc include) error {
+		included := path.Join(path.Dir(name), inc.path)
+		if !fs.ValidPath(included) {
+			return &MapFileError{name, inc.lineNumber, fmt.Errorf("invalid include path %q", inc.path)}
+		}
+		chain := append(append([]string{}, stack...), name)
+		for _, including := range chain {
+			if including == included {
+				return &MapFileError{name, inc.lineNumber,
+					fmt.Errorf("include cycle %s", strings.Join(append(chain, included), " -> "))}
+			}
+		}
+		includedDefs, err := p.parseFile(included, chain)
+		var fileErr *MapFileError
+		if err != nil && !errors.As(err, &fileErr) {
+			// the included file cannot be opened.
+			return &MapFileError{name, inc.lineNumber, err}
+		}
+		if err != nil {
+			return err
+		}
+		if inc.region != "" {
+			prefixCities(includedDefs, inc.region)
+		}
+		defs = append(defs, includedDefs...)
+		return nil
+	}, nil)
+	var (
+		fileErr    *MapFileError
+		includeErr *InvalidIncludeError
+	)
+	switch {
+	case errors.As(err, &includeErr):
+		return nil, &MapFileError{name, includeErr.LineNumber, fmt.Errorf("invalid include directive, %s", includeUsage)}
+	case err != nil && !errors.As(err, &fileErr):
+		return nil, &MapFileError{Path: name, Err: err}
+	}
+	return defs, err
+}
+
+// prefixCities prefixes the names of the cities and the links between them
+// with the region.
+func prefixCities(defs []cityDef, region string) {
+	names := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		names[def.city.Name] = true
+	}
+	prefix := func(name string) string {
+		if names[name] {
+			return region + "-" + name
+		}
+		return name
+	}
+	for _, def := range defs {
+		def.city.Name = prefix(def.city.Name)
+		for direction, neighborName := range def.city.Neighbors {
+			def.city.Neighbors[direction] = prefix(neighborName)
+		}
+	}
+}
+
+// parseInclude parses the line as an include directive. ok is false when the
+// line is not an include directive.
+func parseInclude(line []byte, lineNumber int) (inc include, ok bool, err error) {
+	line = bytes.TrimLeft(line, " \t")
+	if !bytes.HasPrefix(line, includeKeyword) {
+		return include{}, false, nil
+	}
+	if i := bytes.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	// a city named include is followed by a direction and a neighbor.
+	fields := strings.Fields(string(line))
+	if fields[0] != string(includeKeyword) || len(fields) < 2 || strings.Contains(fields[1], "=") {
+		return include{}, false, nil
+	}
+	inc = include{path: fields[1], lineNumber: lineNumber}
+	switch {
+	case len(fields) == 2:
+		return inc, true, nil
+	case len(fields) == 4 && fields[2] == "as" && regionRe.MatchString(fields[3]):
+		inc.region = fields[3]
+		return inc, true, nil
+	}
+	return include{}, true, &InvalidIncludeError{lineNumber}
+}
+
+// includeUsage describes the valid include directives.
+const includeUsage = "expected 'include <path>' or 'include <path> as <region>'"
+
+// InvalidIncludeError is returned when an include directive is not valid.
+type InvalidIncludeError struct {
+	// LineNumber where error is found.
+	LineNumber int
+}
+
+func (e *InvalidIncludeError) Error() string {
+	return fmt.Sprintf("invalid include directive at line '%d', %s", e.LineNumber, includeUsage)
+}
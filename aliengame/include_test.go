@@ -0,0 +1,120 @@
+package aliengame
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMapFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"world.aliengame": {Data: []byte(`
+include continents/europe.aliengame as Europe
+include continents/asia.aliengame
+Atlantis north=Europe-Paris
+`)},
+		"continents/europe.aliengame": {Data: []byte(`
+Paris east=Berlin
+Berlin west=Paris
+include islands.aliengame as Isles
+`)},
+		"continents/islands.aliengame": {Data: []byte("London east=Paris\n")},
+		"continents/asia.aliengame":    {Data: []byte("Istanbul west=Europe-Berlin\n")},
+	}
+	mp, err := ParseMapFS(fsys, "world.aliengame")
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	var buf bytes.Buffer
+	require.NoError(t, PrintMap(&buf, mp))
+	require.Equal(t, `Atlantis north=Europe-Paris
+Europe-Berlin east=Istanbul west=Europe-Paris
+Europe-Isles-London east=Europe-Paris
+Europe-Paris east=Europe-Berlin south=Atlantis west=Europe-Isles-London
+Istanbul west=Europe-Berlin
+`, buf.String())
+}
+
+func TestParseMapFSErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		fsys fstest.MapFS
+		err  string
+	}{
+		{
+			"cycle",
+			fstest.MapFS{
+				"a.aliengame":   {Data: []byte("Foo north=Bar\ninclude b/b.aliengame")},
+				"b/b.aliengame": {Data: []byte("include ../c.aliengame")},
+				"c.aliengame":   {Data: []byte("\n\ninclude a.aliengame")},
+			},
+			"c.aliengame:3: include cycle a.aliengame -> b/b.aliengame -> c.aliengame -> a.aliengame",
+		},
+		{
+			"collision",
+			fstest.MapFS{
+				"a.aliengame": {Data: []byte("Foo north=Bar\ninclude b.aliengame")},
+				"b.aliengame": {Data: []byte("Baz north=Qux\nFoo south=Qux")},
+			},
+			`b.aliengame:2: city "Foo" is already defined at a.aliengame:1`,
+		},
+		{
+			"missing file",
+			fstest.MapFS{
+				"a.aliengame": {Data: []byte("Foo north=Bar\ninclude b.aliengame")},
+			},
+			"a.aliengame:2: open b.aliengame: file does not exist",
+		},
+		{
+			"invalid path",
+			fstest.MapFS{
+				"a.aliengame": {Data: []byte("include ../b.aliengame")},
+			},
+			`a.aliengame:1: invalid include path "../b.aliengame"`,
+		},
+		{
+			"invalid directive",
+			fstest.MapFS{
+				"a.aliengame": {Data: []byte("include b.aliengame\n")},
+				"b.aliengame": {Data: []byte("Foo north=Bar\ninclude c.aliengame as\n")},
+			},
+			"b.aliengame:2: invalid include directive, expected 'include <path>' or 'include <path> as <region>'",
+		},
+		{
+			"invalid city",
+			fstest.MapFS{
+				"a.aliengame": {Data: []byte("include b.aliengame\n")},
+				"b.aliengame": {Data: []byte("Foo up=Bar")},
+			},
+			`b.aliengame: invalid direction "up" found at line '1'`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseMapFS(tt.fsys, "a.aliengame")
+			require.EqualError(t, err, tt.err)
+			var fileErr *MapFileError
+			require.True(t, errors.As(err, &fileErr))
+		})
+	}
+
+	_, err := ParseMapFS(fstest.MapFS{}, "a.aliengame")
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestParseMapInclude(t *testing.T) {
+	// a city can still be named include.
+	mp, err := ParseMap(strings.NewReader("include north=Bar"))
+	require.NoError(t, err)
+	require.Contains(t, mp, "include")
+
+	_, err = ParseMap(strings.NewReader("Foo north=Bar\ninclude b.aliengame"))
+	require.Error(t, err)
+
+	_, err = ParseMap(strings.NewReader("Foo north=Bar\ninclude b.aliengame as"))
+	require.Equal(t, &InvalidIncludeError{2}, err)
+}
@@ -0,0 +1,170 @@
+package aliengame
+
+import (
+	"fmt"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// interventions change the world between iterations from outside of the game,
+// for what-if experiments and player abilities. they emit the same events as
+// the game does, tagged as external, see IsExternal.
+//
+// interventions are recorded with the next iteration in the history, so going
+// back in time also undoes the interventions made after the last iteration.
+
+// DestroyCity destroys the city with the aliens in it as if they fought, and
+// removes the roads to the city.
+func (w *World) DestroyCity(name string) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if err := w.intervene(); err != nil {
+		return err
+	}
+	city, ok := w.mp[name]
+	if !ok {
+		return fmt.Errorf("city %q does not exist", name)
+	}
+	var aliens []*Alien
+	for _, alien := range w.aliens {
+		if alien.CityName == name {
+			aliens = append(aliens, alien)
+		}
+	}
+	w.beginDelta()
+	w.destroyCity(city, aliens, true)
+	w.updateNeighbors(true)
+	return nil
+}
+
+// CutRoad removes the road to the direction of the city, and the road back
+// from the neighbor.
+func (w *World) CutRoad(cityName string, direction compass.Direction) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if err := w.intervene(); err != nil {
+		return err
+	}
+	city, ok := w.mp[cityName]
+	if !ok {
+		return fmt.Errorf("city %q does not exist", cityName)
+	}
+	neighbor, ok := w.mp[city.Neighbors[direction]]
+	if !ok {
+		return fmt.Errorf("there is no road to %s of %q", direction, cityName)
+	}
+	w.beginDelta()
+	w.recordCity(city.Name)
+	w.recordCity(neighbor.Name)
+	if err := w.mp.Disconnect(cityName, direction); err != nil {
+		return err
+	}
+	w.sendEvent(RoadCutEvent{
+		City:      city,
+		Direction: direction,
+		Neighbor:  neighbor,
+	})
+	w.updateNeighbors(true)
+	return nil
+}
+
+// BuildRoad builds a road from the city to the neighbor in the direction, and
+// the road back from the neighbor. the directions must not be used by other
+// roads. aliens trapped in the cities can move again.
+func (w *World) BuildRoad(cityName string, direction compass.Direction, neighborName string) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if err := w.intervene(); err != nil {
+		return err
+	}
+	// the road is checked before the history records the cities.
+	direction, err := w.mp.checkConnect(cityName, direction, neighborName)
+	if err != nil {
+		return err
+	}
+	city, neighbor := w.mp[cityName], w.mp[neighborName]
+	if city.Neighbors[direction] == neighborName {
+		return fmt.Errorf("%s of %q is already connected to %q", direction, cityName, neighborName)
+	}
+	w.beginDelta()
+	w.recordCity(city.Name)
+	w.recordCity(neighbor.Name)
+	if err := w.mp.Connect(cityName, direction, neighborName); err != nil {
+		return err
+	}
+	w.untrap(city)
+	w.untrap(neighbor)
+	w.sendEvent(RoadBuiltEvent{
+		City:      city,
+		Direction: direction,
+		Neighbor:  neighbor,
+	})
+	return nil
+}
+
+// KillAlien kills the living alien without destroying its city.
+func (w *World) KillAlien(name string) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if err := w.intervene(); err != nil {
+		return err
+	}
+	alien := w.alien(name)
+	if alien == nil {
+		return fmt.Errorf("alien %q is not living", name)
+	}
+	w.beginDelta()
+	for i := range w.aliens {
+		if w.aliens[i] == alien {
+			w.aliens = append(w.aliens[:i], w.aliens[i+1:]...)
+			break
+		}
+	}
+	w.killedAliens = append(w.killedAliens, name)
+	if c, ok := w.controls[name]; ok {
+		c.survived = w.iteration - c.since
+		w.controls[name] = c
+	}
+	w.sendEvent(AlienKilledEvent{
+		City:  w.mp[alien.CityName],
+		Alien: alien,
+	})
+	return nil
+}
+
+// TeleportAlien moves the living alien to the city. the alien is not trapped
+// anymore, and it fights at the next iteration if it meets other aliens there,
+// like the spawned aliens do.
+func (w *World) TeleportAlien(alienName, cityName string) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if err := w.intervene(); err != nil {
+		return err
+	}
+	alien := w.alien(alienName)
+	if alien == nil {
+		return fmt.Errorf("alien %q is not living", alienName)
+	}
+	to, ok := w.mp[cityName]
+	if !ok {
+		return fmt.Errorf("city %q does not exist", cityName)
+	}
+	w.beginDelta()
+	from := w.mp[alien.CityName]
+	alien.CityName = cityName
+	alien.IsTrapped = false
+	w.sendEvent(AlienTeleportedEvent{
+		From:  from,
+		To:    to,
+		Alien: alien,
+	})
+	return nil
+}
+
+// intervene checks if the world can be intervened.
+func (w *World) intervene() error {
+	if w.done {
+		return fmt.Errorf("world has ended")
+	}
+	return nil
+}
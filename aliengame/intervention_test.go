@@ -0,0 +1,148 @@
+package aliengame
+
+import (
+	"testing"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+// lineMapdef is the Foo-Bar-Baz line from south to north.
+const lineMapdef = "Foo north=Bar\nBar north=Baz"
+
+// requireExternal checks if all the events are caused by interventions.
+func requireExternal(t *testing.T, events []Event) {
+	for _, event := range events {
+		require.True(t, IsExternal(event), event.String())
+	}
+}
+
+func TestDestroyCity(t *testing.T) {
+	world := newTestWorld(t, lineMapdef, 0, map[string]string{"X": "Bar", "Y": "Foo"})
+	events := world.Collect(func() { require.NoError(t, world.DestroyCity("Bar")) })
+	requireExternal(t, events)
+	require.Len(t, events, 3)
+	require.Equal(t, "Bar", events[0].(CityDestroyedEvent).City.Name)
+	require.Equal(t, "X", events[0].(CityDestroyedEvent).Aliens[0].Name)
+	for _, event := range events[1:] {
+		require.IsType(t, CityHasNoNeighborsEvent{}, event)
+	}
+	require.Equal(t, 2, world.LivingCityCount())
+	_, ok := world.Alien("X")
+	require.False(t, ok)
+	require.Equal(t, []DestroyedCity{{Name: "Bar", Aliens: []string{"X"}, External: true}}, world.Stats().DestroyedCities)
+	require.Error(t, world.DestroyCity("Bar"))
+
+	// Y is trapped in Foo and the world ends.
+	require.False(t, world.Resume())
+	require.Error(t, world.DestroyCity("Foo"))
+}
+
+func TestCutAndBuildRoad(t *testing.T) {
+	world := newTestWorld(t, lineMapdef, 0, map[string]string{"X": "Foo", "Y": "Baz"})
+	events := world.Collect(func() { require.NoError(t, world.CutRoad("Foo", compass.North)) })
+	requireExternal(t, events)
+	require.Len(t, events, 2)
+	require.Equal(t, "Bar", events[0].(RoadCutEvent).Neighbor.Name)
+	require.Equal(t, "Foo", events[1].(CityHasNoNeighborsEvent).City.Name)
+	require.Error(t, world.CutRoad("Foo", compass.North))
+	require.Error(t, world.CutRoad("Qux", compass.North))
+
+	// X is trapped in Foo, Y moves to Bar.
+	require.True(t, world.Resume())
+	x, _ := world.Alien("X")
+	require.True(t, x.IsTrapped)
+
+	require.Error(t, world.BuildRoad("Foo", compass.North, "Qux"))
+	require.Error(t, world.BuildRoad("Bar", compass.North, "Foo"))
+	events = world.Collect(func() { require.NoError(t, world.BuildRoad("Foo", compass.West, "Bar")) })
+	requireExternal(t, events)
+	require.Len(t, events, 1)
+	require.Equal(t, "Bar", events[0].(RoadBuiltEvent).Neighbor.Name)
+	require.Error(t, world.BuildRoad("Foo", compass.West, "Bar"))
+	foo, _ := world.City("Foo")
+	require.False(t, foo.HasNoNeighbors)
+	require.Equal(t, map[compass.Direction]string{compass.West: "Bar"}, foo.Neighbors)
+	x, _ = world.Alien("X")
+	require.False(t, x.IsTrapped)
+
+	// X moves to Bar through the new road, while Y leaves Bar.
+	require.True(t, world.Resume())
+	x, _ = world.Alien("X")
+	require.Equal(t, "Bar", x.CityName)
+}
+
+func TestKillAndTeleportAlien(t *testing.T) {
+	world := newTestWorld(t, lineMapdef, 0, map[string]string{"Z": "Foo"}, WithTurnTimeout(0))
+	require.NoError(t, world.AddPlayer("p", "Z"))
+	world.Resume()
+	world.Resume()
+
+	events := world.Collect(func() { require.NoError(t, world.KillAlien("Z")) })
+	requireExternal(t, events)
+	require.Len(t, events, 1)
+	require.Equal(t, "Z", events[0].(AlienKilledEvent).Alien.Name)
+	_, ok := world.Alien("Z")
+	require.False(t, ok)
+	require.Error(t, world.KillAlien("Z"))
+	require.Equal(t, 2, world.Scores()[0].SurvivedIterations)
+	require.Equal(t, []string{"Z"}, world.Stats().KilledAliens)
+
+	require.NoError(t, world.SpawnAlienAt("Y", "Baz"))
+	events = world.Collect(func() { require.NoError(t, world.TeleportAlien("Y", "Foo")) })
+	requireExternal(t, events)
+	require.Len(t, events, 1)
+	require.Equal(t, "Foo", events[0].(AlienTeleportedEvent).To.Name)
+	require.Error(t, world.TeleportAlien("W", "Foo"))
+	require.Error(t, world.TeleportAlien("Y", "Qux"))
+	require.Equal(t, []Alien{{Name: "Y", CityName: "Foo"}}, world.AliensIn("Foo"))
+}
+
+func TestSpawnAfterKill(t *testing.T) {
+	world := newTestWorld(t, lineMapdef, 1, nil, WithTurnTimeout(0))
+	require.NoError(t, world.AddPlayer("p", "A1"))
+	require.NoError(t, world.KillAlien("A1"))
+
+	// the new alien does not take the name of the killed one, or its player.
+	world.SpawnAlien(1)
+	_, ok := world.Alien("A2")
+	require.True(t, ok)
+	require.Error(t, world.SpawnAlienAt("A1", "Foo"))
+	world.Resume()
+	require.Empty(t, world.Scores()[0].Survivors)
+}
+
+func TestInterventionHistory(t *testing.T) {
+	world := newTestWorld(t, lineMapdef, 0, map[string]string{"X": "Foo"}, WithHistory(0))
+	world.Resume()
+	frame := world.Frame()
+	require.NoError(t, world.DestroyCity("Baz"))
+	world.Resume()
+	stats := world.Stats()
+
+	require.NoError(t, world.JumpTo(1))
+	require.Equal(t, frame, world.Frame())
+	require.Empty(t, world.Stats().DestroyedCities)
+	// failed interventions do not overwrite the undone iterations.
+	require.Error(t, world.BuildRoad("Foo", compass.Direction("Up"), "Bar"))
+	require.NoError(t, world.JumpTo(2))
+	require.Equal(t, stats, world.Stats())
+	require.Equal(t, []DestroyedCity{{Name: "Baz", Iteration: 1, External: true}}, stats.DestroyedCities)
+
+	// interventions after the last iteration are undone by going back.
+	require.NoError(t, world.DestroyCity("Foo"))
+	require.NoError(t, world.JumpTo(2))
+	require.Equal(t, stats, world.Stats())
+	require.NoError(t, world.KillAlien("X"))
+	require.Equal(t, []string{"X"}, world.Stats().KilledAliens)
+	require.NoError(t, world.JumpTo(2))
+	require.Equal(t, stats, world.Stats())
+
+	// the killed aliens are kept with the next iteration.
+	require.NoError(t, world.KillAlien("X"))
+	world.Resume()
+	require.NoError(t, world.JumpTo(2))
+	require.Empty(t, world.Stats().KilledAliens)
+	require.NoError(t, world.JumpTo(3))
+	require.Equal(t, []string{"X"}, world.Stats().KilledAliens)
+}
@@ -0,0 +1,41 @@
+package aliengame
+
+import "fmt"
+
+// WithInvariantCheck makes the world call check with its map and living aliens
+// after every iteration to verify the consistency of the game engine, as a
+// debug mode. check must not modify the map and aliens, see the
+// aliengame/invariants package for the checks.
+//
+// the world ends when check returns an error, and the error is returned by Run
+// and Err. the world panics with the error instead if panicOnViolation is true.
+func WithInvariantCheck(check func(mp Map, aliens []Alien) error, panicOnViolation bool) Option {
+	return func(w *World) {
+		w.check = check
+		w.panicOnViolation = panicOnViolation
+	}
+}
+
+// Err returns the invariant violation that has ended the world, if any.
+func (w *World) Err() error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return w.err
+}
+
+// checkInvariants checks the invariants of the world if a check is set.
+func (w *World) checkInvariants() error {
+	if w.check == nil {
+		return nil
+	}
+	err := w.check(w.mp, w.alienList())
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("invariant violation after iteration %d: %w", w.iteration, err)
+	if w.panicOnViolation {
+		panic(err)
+	}
+	w.err = err
+	return err
+}
@@ -0,0 +1,41 @@
+package aliengame
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithInvariantCheck(t *testing.T) {
+	mp, err := ParseMap(strings.NewReader("Foo north=Bar\nBar north=Baz"))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+
+	var calls int
+	errBroken := errors.New("broken")
+	check := func(mp Map, aliens []Alien) error {
+		calls++
+		require.Len(t, aliens, 1)
+		if calls == 2 {
+			return errBroken
+		}
+		return nil
+	}
+	world := New(mp, nil, WithInvariantCheck(check, false))
+	world.SpawnAlien(1)
+	result, err := world.Run(context.Background(), RunOptions{})
+	require.True(t, errors.Is(err, errBroken))
+	require.EqualError(t, err, "invariant violation after iteration 2: broken")
+	require.Equal(t, RunResult{InvariantViolated, 2}, result)
+	require.Equal(t, err, world.Err())
+	require.True(t, world.Ended())
+	require.False(t, world.Resume())
+	require.Equal(t, 2, calls)
+
+	world = New(mp, nil, WithInvariantCheck(func(Map, []Alien) error { return errBroken }, true))
+	world.SpawnAlien(1)
+	require.PanicsWithError(t, "invariant violation after iteration 1: broken", func() { world.Resume() })
+}
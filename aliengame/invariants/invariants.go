@@ -0,0 +1,213 @@
+// Package invariants checks the consistency of the game states and events of
+// aliengame worlds. tests, fuzzers and debug modes can use it after every
+// world.Resume() to catch the bugs of the game engine early.
+package invariants
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// Error is returned when some of the invariants are violated.
+type Error struct {
+	// Violations explain the violated invariants.
+	Violations []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d invariant violations:\n\t%s", len(e.Violations), strings.Join(e.Violations, "\n\t"))
+}
+
+// CheckMap checks the invariants of a map:
+// - every neighbor link points to an existing city that links back.
+// - only the cities without neighbors are marked with HasNoNeighbors.
+func CheckMap(mp aliengame.Map) error {
+	return errorOf(checkMap(mp))
+}
+
+// Check checks the invariants of a map and the living aliens on it, right
+// after a world is resumed. on top of the map invariants:
+// - cities without neighbors are marked with HasNoNeighbors.
+// - alien names are unique.
+// - every alien is in an existing city, aliens of the destroyed cities are dead.
+// - trapped aliens are in the cities without neighbors.
+func Check(mp aliengame.Map, aliens []aliengame.Alien) error {
+	violations := checkMap(mp)
+	for _, name := range cityNames(mp) {
+		city := mp[name]
+		if len(city.Neighbors) == 0 && !city.HasNoNeighbors {
+			violations = append(violations, fmt.Sprintf("city %q has no neighbors but it is not marked", name))
+		}
+	}
+	seen := make(map[string]bool)
+	for _, alien := range aliens {
+		if seen[alien.Name] {
+			violations = append(violations, fmt.Sprintf("alien name %q is not unique", alien.Name))
+		}
+		seen[alien.Name] = true
+		city, ok := mp[alien.CityName]
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Sprintf("alien %q is in city %q that does not exist",
+				alien.Name, alien.CityName))
+		case alien.IsTrapped && len(city.Neighbors) > 0:
+			violations = append(violations, fmt.Sprintf("alien %q is trapped in city %q that has neighbors",
+				alien.Name, alien.CityName))
+		}
+	}
+	return errorOf(violations)
+}
+
+// CheckWorld checks the invariants of the current state of the world, see
+// Check.
+func CheckWorld(w *aliengame.World) error {
+	return Check(w.Map(), w.Aliens())
+}
+
+// Events checks the invariants of the game events emitted by a world:
+// - a city is destroyed at most once until it is rebuilt.
+// - a city is reported to have no neighbors once until a road is built to it.
+// - no events are emitted for the cities after they are destroyed.
+//
+// the zero value is ready to use.
+type Events struct {
+	noNeighbors map[string]bool
+	destroyed   map[string]bool
+}
+
+// Observe checks the next event emitted by the world.
+func (c *Events) Observe(e aliengame.Event) error {
+	if c.destroyed == nil {
+		c.destroyed = make(map[string]bool)
+		c.noNeighbors = make(map[string]bool)
+	}
+	var violations []string
+	switch e := e.(type) {
+	case aliengame.CityDestroyedEvent:
+		if c.destroyed[e.City.Name] {
+			violations = append(violations, fmt.Sprintf("city %q is destroyed more than once", e.City.Name))
+		}
+		c.destroyed[e.City.Name] = true
+	case aliengame.CityHasNoNeighborsEvent:
+		switch {
+		case c.destroyed[e.City.Name]:
+			violations = append(violations, fmt.Sprintf("destroyed city %q is reported to have no neighbors",
+				e.City.Name))
+		case c.noNeighbors[e.City.Name]:
+			violations = append(violations, fmt.Sprintf("city %q is reported to have no neighbors more than once",
+				e.City.Name))
+		}
+		c.noNeighbors[e.City.Name] = true
+	case aliengame.AlienTrappedEvent:
+		if c.destroyed[e.City.Name] {
+			violations = append(violations, fmt.Sprintf("alien %q is trapped in destroyed city %q",
+				e.Alien.Name, e.City.Name))
+		}
+	case aliengame.RoadCutEvent:
+		violations = c.checkRoad(violations, "cut", e.City, e.Neighbor)
+	case aliengame.RoadBuiltEvent:
+		violations = c.checkRoad(violations, "built", e.City, e.Neighbor)
+		c.noNeighbors[e.City.Name] = false
+		c.noNeighbors[e.Neighbor.Name] = false
+	case aliengame.CityRebuiltEvent:
+		if !c.destroyed[e.City.Name] {
+			violations = append(violations, fmt.Sprintf("city %q is rebuilt before it is destroyed", e.City.Name))
+		}
+		c.build(e.City)
+	case aliengame.CityFoundedEvent:
+		c.build(e.City)
+	case aliengame.AlienKilledEvent:
+		if c.destroyed[e.City.Name] {
+			violations = append(violations, fmt.Sprintf("alien %q is killed in destroyed city %q",
+				e.Alien.Name, e.City.Name))
+		}
+	case aliengame.AlienTeleportedEvent:
+		if c.destroyed[e.To.Name] {
+			violations = append(violations, fmt.Sprintf("alien %q is teleported to destroyed city %q",
+				e.Alien.Name, e.To.Name))
+		}
+	}
+	return errorOf(violations)
+}
+
+// build resets the state of the city and its neighbors when it is built.
+func (c *Events) build(city *aliengame.City) {
+	c.destroyed[city.Name] = false
+	c.noNeighbors[city.Name] = city.HasNoNeighbors
+	for _, neighborName := range city.Neighbors {
+		c.noNeighbors[neighborName] = false
+	}
+}
+
+// checkRoad checks that the road between the cities is not cut or built after
+// one of them is destroyed.
+func (c *Events) checkRoad(violations []string, action string, city, neighbor *aliengame.City) []string {
+	for _, name := range []string{city.Name, neighbor.Name} {
+		if c.destroyed[name] {
+			violations = append(violations, fmt.Sprintf("road between %q and %q is %s after %q is destroyed",
+				city.Name, neighbor.Name, action, name))
+		}
+	}
+	return violations
+}
+
+// checkMap checks the invariants of mp.
+func checkMap(mp aliengame.Map) (violations []string) {
+	for _, name := range cityNames(mp) {
+		city := mp[name]
+		if city.Name != name {
+			violations = append(violations, fmt.Sprintf("city %q is named %q", name, city.Name))
+		}
+		if city.HasNoNeighbors && len(city.Neighbors) > 0 {
+			violations = append(violations, fmt.Sprintf("city %q is marked to have no neighbors but it has",
+				name))
+		}
+		for _, direction := range compass.Directions {
+			neighborName, ok := city.Neighbors[direction]
+			if !ok {
+				continue
+			}
+			neighbor, ok := mp[neighborName]
+			revDirection := compass.ReverseDirection(direction)
+			switch {
+			case !ok:
+				violations = append(violations, fmt.Sprintf("%s of %q is the dangling neighbor %q",
+					direction, name, neighborName))
+			case neighbor.Neighbors[revDirection] != name:
+				violations = append(violations, fmt.Sprintf("%s of %q is %q but %s of %q is %q",
+					direction, name, neighborName, revDirection, neighborName, neighbor.Neighbors[revDirection]))
+			}
+		}
+	}
+	return violations
+}
+
+// cityNames returns the names of the cities in mp sorted alphabetically, so
+// the violations are reported in a stable order.
+func cityNames(mp aliengame.Map) []string {
+	var names []string
+	for name := range mp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// errorOf returns an *Error for the violations, or nil if there are none.
+func errorOf(violations []string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &Error{violations}
+}
+
+// WithChecks returns a world option to check the invariants of the world after
+// every iteration, see aliengame.WithInvariantCheck. the world panics on
+// violations if panicOnViolation is true.
+func WithChecks(panicOnViolation bool) aliengame.Option {
+	return aliengame.WithInvariantCheck(Check, panicOnViolation)
+}
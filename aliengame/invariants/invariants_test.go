@@ -0,0 +1,223 @@
+package invariants
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	mp := aliengame.Map{
+		"Foo": &aliengame.City{Name: "Foo", Neighbors: map[compass.Direction]string{
+			compass.North: "Bar",
+			compass.East:  "Baz",
+			compass.West:  "Qux",
+		}},
+		"Bar": &aliengame.City{Name: "Bar", Neighbors: map[compass.Direction]string{
+			compass.South: "Foo",
+		}},
+		"Baz": &aliengame.City{Name: "Baz", HasNoNeighbors: true, Neighbors: map[compass.Direction]string{
+			compass.South: "Foo",
+		}},
+		"Yee": &aliengame.City{Name: "Yee"},
+	}
+	aliens := []aliengame.Alien{
+		{Name: "A1", CityName: "Foo", IsTrapped: true},
+		{Name: "A1", CityName: "Yee"},
+		{Name: "A2", CityName: "Qux"},
+	}
+	require.Equal(t, &Error{[]string{
+		`city "Baz" is marked to have no neighbors but it has`,
+		`South of "Baz" is "Foo" but North of "Foo" is "Bar"`,
+		`East of "Foo" is "Baz" but West of "Baz" is ""`,
+		`West of "Foo" is the dangling neighbor "Qux"`,
+	}}, CheckMap(mp))
+	require.Equal(t, &Error{[]string{
+		`city "Baz" is marked to have no neighbors but it has`,
+		`South of "Baz" is "Foo" but North of "Foo" is "Bar"`,
+		`East of "Foo" is "Baz" but West of "Baz" is ""`,
+		`West of "Foo" is the dangling neighbor "Qux"`,
+		`city "Yee" has no neighbors but it is not marked`,
+		`alien "A1" is trapped in city "Foo" that has neighbors`,
+		`alien name "A1" is not unique`,
+		`alien "A2" is in city "Qux" that does not exist`,
+	}}, Check(mp, aliens))
+
+	mp, err := aliengame.ParseMap(strings.NewReader("Foo north=Bar west=Baz south=Qu-ux\nBee south=Bar\nYee west=Bar"))
+	require.NoError(t, err)
+	require.NoError(t, aliengame.CraftMap(mp))
+	require.NoError(t, CheckMap(mp))
+	world := aliengame.New(mp, nil, aliengame.WithSeed(1))
+	world.SpawnAlien(3)
+	for world.Resume() {
+		require.NoError(t, CheckWorld(world))
+	}
+	require.NoError(t, CheckWorld(world))
+}
+
+func TestEvents(t *testing.T) {
+	foo := &aliengame.City{Name: "Foo"}
+	bar := &aliengame.City{Name: "Bar"}
+	var c Events
+	require.NoError(t, c.Observe(aliengame.CityHasNoNeighborsEvent{City: foo}))
+	require.NoError(t, c.Observe(aliengame.CityDestroyedEvent{City: bar}))
+	require.Error(t, c.Observe(aliengame.CityHasNoNeighborsEvent{City: foo}))
+	require.Error(t, c.Observe(aliengame.CityDestroyedEvent{City: bar}))
+	require.Error(t, c.Observe(aliengame.CityHasNoNeighborsEvent{City: bar}))
+	require.Error(t, c.Observe(aliengame.AlienTrappedEvent{City: bar, Alien: &aliengame.Alien{Name: "A1"}}))
+	require.Error(t, c.Observe(aliengame.RoadBuiltEvent{City: foo, Direction: compass.North, Neighbor: bar}))
+
+	// a city can lose its neighbors again after a road is built to it.
+	baz := &aliengame.City{Name: "Baz"}
+	require.NoError(t, c.Observe(aliengame.RoadBuiltEvent{City: foo, Direction: compass.North, Neighbor: baz}))
+	require.NoError(t, c.Observe(aliengame.CityHasNoNeighborsEvent{City: foo}))
+
+	// a city can be destroyed again after it is rebuilt.
+	require.Error(t, c.Observe(aliengame.CityRebuiltEvent{City: baz}))
+	require.NoError(t, c.Observe(aliengame.CityRebuiltEvent{City: bar}))
+	require.NoError(t, c.Observe(aliengame.CityDestroyedEvent{City: bar}))
+}
+
+// FuzzInterventions plays games on a fuzzed map with rebuilds and foundings,
+// while making the interventions encoded by ops between iterations, and checks
+// the invariants after each.
+func FuzzInterventions(f *testing.F) {
+	f.Add("Foo north=Bar west=Baz south=Qu-ux\nBee south=Bar\nYee west=Bar", int64(1), []byte{0, 1, 2, 3, 4, 5, 6, 7})
+	f.Add("Foo north=Bar\nBar north=Baz\nBaz north=Qux", int64(2), []byte{3, 9, 14, 1, 20, 2})
+	f.Fuzz(func(t *testing.T, mapdef string, seed int64, ops []byte) {
+		mp, err := aliengame.ParseMap(strings.NewReader(mapdef))
+		// big maps and long games are too slow to fuzz.
+		if err != nil || len(mp) == 0 || len(mp) > 64 || len(ops) > 64 {
+			return
+		}
+		if aliengame.CraftMap(mp) != nil || CheckMap(mp) != nil {
+			return
+		}
+		// New is founded next to a city at the second iteration.
+		cityNames := cityNames(mp)
+		founding := aliengame.Founding{Iteration: 2, City: &aliengame.City{
+			Name:      "New",
+			Neighbors: map[compass.Direction]string{compass.North: cityNames[0]},
+		}}
+		world := aliengame.New(mp, nil, aliengame.WithSeed(seed), WithChecks(true), aliengame.WithHistory(4),
+			aliengame.WithRebuild(3), aliengame.WithFoundings(founding))
+		world.SpawnAlien(len(mp))
+		var events Events
+		for i, op := range ops {
+			city := cityNames[int(op)%len(cityNames)]
+			direction := compass.Directions[int(op)/len(cityNames)%len(compass.Directions)]
+			neighbor := cityNames[i%len(cityNames)]
+			alien := fmt.Sprintf("A%d", int(op)%len(mp)+1)
+			sub := world.Subscribe(len(mp)*8 + 8)
+			// interventions are allowed to fail, on the destroyed cities for
+			// example.
+			switch op % 6 {
+			case 0:
+				world.DestroyCity(city)
+			case 1:
+				world.CutRoad(city, direction)
+			case 2:
+				world.BuildRoad(city, direction, neighbor)
+			case 3:
+				world.KillAlien(alien)
+			case 4:
+				world.TeleportAlien(alien, city)
+			case 5:
+				// the undone events are observed again after going back in
+				// time.
+				world.StepBack()
+				events = eventsOf(world)
+			}
+			require.NoError(t, CheckWorld(world))
+			canResume := world.Resume()
+			sub.Cancel()
+			require.False(t, sub.Dropped())
+			for event := range sub.C {
+				require.NoError(t, events.Observe(event))
+			}
+			if !canResume {
+				return
+			}
+		}
+	})
+}
+
+// eventsOf returns an Events that has observed the events of the world till
+// its current state.
+func eventsOf(world *aliengame.World) Events {
+	events := Events{destroyed: make(map[string]bool), noNeighbors: make(map[string]bool)}
+	for _, city := range world.Stats().DestroyedCities {
+		events.destroyed[city.Name] = true
+	}
+	for name, city := range world.Map() {
+		events.destroyed[name] = false
+		events.noNeighbors[name] = city.HasNoNeighbors
+	}
+	return events
+}
+
+// FuzzGame plays games on fuzzed maps and checks the invariants after every
+// iteration.
+func FuzzGame(f *testing.F) {
+	f.Add("Foo north=Bar west=Baz south=Qu-ux\nBee south=Bar\nYee west=Bar", int64(1), uint8(3))
+	f.Add("Foo north=Bar\nBar north=Baz\nBaz north=Qux", int64(2), uint8(2))
+	f.Add("Foo north=Foo east=Bar", int64(3), uint8(5))
+	f.Fuzz(func(t *testing.T, mapdef string, seed int64, alienCount uint8) {
+		mp, err := aliengame.ParseMap(strings.NewReader(mapdef))
+		if err != nil || aliengame.CraftMap(mp) != nil {
+			return
+		}
+		// CraftMap cannot fix the maps that link different cities to the
+		// same direction of a city.
+		if CheckMap(mp) != nil {
+			return
+		}
+		world := aliengame.New(mp, nil, aliengame.WithSeed(seed))
+		world.SpawnAlien(int(alienCount % 16))
+		var events Events
+		for i := 0; i < 50; i++ {
+			sub := world.Subscribe(len(mp)*2 + int(alienCount))
+			canResume := world.Resume()
+			sub.Cancel()
+			require.False(t, sub.Dropped())
+			for event := range sub.C {
+				require.NoError(t, events.Observe(event))
+			}
+			require.NoError(t, CheckWorld(world))
+			if !canResume {
+				return
+			}
+		}
+	})
+}
+
+func TestWithChecks(t *testing.T) {
+	mp, err := aliengame.ParseMap(strings.NewReader("Foo north=Bar west=Baz south=Qu-ux\nBee south=Bar\nYee west=Bar"))
+	require.NoError(t, err)
+	require.NoError(t, aliengame.CraftMap(mp))
+	for seed := int64(0); seed < 20; seed++ {
+		world := aliengame.New(mp, nil, aliengame.WithSeed(seed), WithChecks(true))
+		world.SpawnAlien(4)
+		require.NotPanics(t, func() {
+			_, err := world.Run(context.Background(), aliengame.RunOptions{})
+			require.NoError(t, err)
+		})
+	}
+
+	// aliens spawned later do not take the names of the living ones.
+	world := aliengame.New(mp, nil, WithChecks(true))
+	world.SpawnAlien(2)
+	require.NoError(t, world.SpawnAlienAt("A4", "Foo"))
+	world.SpawnAlien(3)
+	var names []string
+	for _, alien := range world.Aliens() {
+		names = append(names, alien.Name)
+	}
+	require.ElementsMatch(t, []string{"A1", "A2", "A3", "A4", "A5", "A6"}, names)
+	require.NotPanics(t, func() { world.Resume() })
+}
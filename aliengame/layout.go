@@ -0,0 +1,121 @@
+package aliengame
+
+import (
+	"sort"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// Point is a position on a 2D grid where x grows to the east and y grows to
+// the south.
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Layout places cities on a 2D grid by inferring their coordinates from the
+// compass directions between them. a city is placed next to its neighbor in
+// the neighbor's direction when that place is free, otherwise it is pushed
+// further in the same direction.
+// components are placed side by side from west to east, and all coordinates
+// are non-negative.
+func (mp Map) Layout() map[string]Point {
+	layout := make(map[string]Point, len(mp))
+	links := mp.compassLinks()
+	var offsetX int
+	for _, component := range mp.Components() {
+		local := layoutComponent(links, component[0])
+		// shift the component next to the previous one.
+		minX, minY, maxX := 0, 0, 0
+		for _, p := range local {
+			if p.X < minX {
+				minX = p.X
+			}
+			if p.X > maxX {
+				maxX = p.X
+			}
+			if p.Y < minY {
+				minY = p.Y
+			}
+		}
+		for name, p := range local {
+			layout[name] = Point{p.X - minX + offsetX, p.Y - minY}
+		}
+		offsetX += maxX - minX + 2
+	}
+	return layout
+}
+
+// compassLink is a link to a neighbor city in a direction.
+type compassLink struct {
+	direction compass.Direction
+	name      string
+}
+
+// compassLinks returns the links of each city to its neighbors including the
+// reverse of the links pointing to the city, so maps that are not crafted or
+// that have conflicting directions can be fully walked through.
+// links are sorted by the compass order and then by name. neighbors that do not
+// exist in the map are ignored.
+func (mp Map) compassLinks() map[string][]compassLink {
+	seen := make(map[string]map[compassLink]bool, len(mp))
+	for name := range mp {
+		seen[name] = make(map[compassLink]bool)
+	}
+	for name, city := range mp {
+		for direction, neighborName := range city.Neighbors {
+			if _, ok := mp[neighborName]; !ok || neighborName == name {
+				continue
+			}
+			seen[name][compassLink{direction, neighborName}] = true
+			seen[neighborName][compassLink{compass.ReverseDirection(direction), name}] = true
+		}
+	}
+	order := make(map[compass.Direction]int)
+	for i, direction := range compass.Directions {
+		order[direction] = i
+	}
+	links := make(map[string][]compassLink, len(mp))
+	for name, cityLinks := range seen {
+		for link := range cityLinks {
+			links[name] = append(links[name], link)
+		}
+		sort.Slice(links[name], func(i, j int) bool {
+			a, b := links[name][i], links[name][j]
+			if a.direction != b.direction {
+				return order[a.direction] < order[b.direction]
+			}
+			return a.name < b.name
+		})
+	}
+	return links
+}
+
+// layoutComponent places all cities reachable from the origin city relative to
+// it. origin is placed at (0, 0).
+func layoutComponent(links map[string][]compassLink, origin string) map[string]Point {
+	layout := map[string]Point{origin: {}}
+	occupied := map[Point]bool{{}: true}
+	queue := []string{origin}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, link := range links[name] {
+			if _, ok := layout[link.name]; ok {
+				continue
+			}
+			dx, dy := compass.Offset(link.direction)
+			p := layout[name]
+			for {
+				p = Point{p.X + dx, p.Y + dy}
+				if !occupied[p] {
+					break
+				}
+			}
+			layout[link.name] = p
+			occupied[p] = true
+			queue = append(queue, link.name)
+		}
+	}
+	return layout
+}
@@ -0,0 +1,46 @@
+package aliengame
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayout(t *testing.T) {
+	mapdef := `
+Foo north=Bar west=Baz south=Qu-ux
+Bee south=Bar
+Yee west=Bar
+Zed east=Lee
+`
+	mp, err := ParseMap(strings.NewReader(mapdef))
+	require.NoError(t, err)
+	expected := map[string]Point{
+		"Bar":   {1, 1},
+		"Bee":   {1, 0},
+		"Yee":   {2, 1},
+		"Foo":   {1, 2},
+		"Baz":   {0, 2},
+		"Qu-ux": {1, 3},
+		"Lee":   {5, 0},
+		"Zed":   {4, 0},
+	}
+	require.NoError(t, CraftMap(mp))
+	require.Equal(t, expected, mp.Layout())
+}
+
+func TestLayoutConflict(t *testing.T) {
+	// Bar and Baz both claim to be on the north of Foo.
+	mp := Map{
+		"Foo": &City{Name: "Foo", Neighbors: map[compass.Direction]string{compass.North: "Bar"}},
+		"Bar": &City{Name: "Bar", Neighbors: map[compass.Direction]string{compass.South: "Foo"}},
+		"Baz": &City{Name: "Baz", Neighbors: map[compass.Direction]string{compass.South: "Foo"}},
+	}
+	require.Equal(t, map[string]Point{
+		"Bar": {0, 1},
+		"Baz": {0, 0},
+		"Foo": {0, 2},
+	}, mp.Layout())
+}
@@ -2,12 +2,15 @@ package aliengame
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
-	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/ilgooz/aliengame/x/compass"
 )
@@ -18,71 +21,314 @@ type Map map[string]*City // city name - city pair.
 // City is a city in the game map.
 type City struct {
 	// Name is the unique name of the city.
-	Name string
+	Name string `json:"name"`
 
 	// HasNoNeighbors shows if city has neighbor cities around it.
-	HasNoNeighbors bool
+	HasNoNeighbors bool `json:"hasNoNeighbors"`
 
 	// Neighbors are the neighbor cities of the city. neighbors have direct paths
 	// (directions) to the city.
 	// direction information is relative to the city not a neighbor.
-	Neighbors map[compass.Direction]string // direction - neighbor city name pair.
+	Neighbors map[compass.Direction]string `json:"neighbors"` // direction - neighbor city name pair.
+
+	// Comments are the comment lines right before the city in the map
+	// defination and TrailingComment is the comment at the end of its line,
+	// without the leading '#'s. they are kept to print the map back.
+	Comments        []string `json:"comments,omitempty"`
+	TrailingComment string   `json:"trailingComment,omitempty"`
 }
 
-// cityRe regexp used to parse city, neighbor city and direction information from
-// each line of the map defination format.
-// city names expected to be in unicode word chars and can optionally contain dashes.
-//
-// TODO check `=` token to ensure that only `=` used to bind directions and cities
-// together. right now parser does not complain about what token is used.
-//
-// TODO maybe manually split lines by space and `=` for optimization?
-var cityRe = regexp.MustCompile(`(?m)[\p{L}\d_-]+`)
+// Clone returns a deep copy of the map.
+func (mp Map) Clone() Map {
+	clone := make(Map, len(mp))
+	for name, city := range mp {
+		clone[name] = city.Clone()
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the city.
+func (c *City) Clone() *City {
+	clone := *c
+	if c.Neighbors != nil {
+		clone.Neighbors = make(map[compass.Direction]string, len(c.Neighbors))
+		for direction, neighborName := range c.Neighbors {
+			clone.Neighbors[direction] = neighborName
+		}
+	}
+	return &clone
+}
 
 // ParseMap parses a map defination by reading from r. it then returns the Map
 // representation of the given defination. error is not nil when the defination
 // file is syntactically not correct or when compass direction is invalid.
 func ParseMap(r io.Reader) (Map, error) {
 	mp := make(Map)
-	lr := bufio.NewReader(r)
-	var lineNumber int
+	err := ParseMapFunc(r, func(city *City) error {
+		mp[city.Name] = city
+		return nil
+	})
+	return mp, err
+}
+
+// ParseMapFunc parses a map defination by reading from r line by line and
+// calls fn with each city defined in a line, so huge maps can be processed
+// without keeping them in memory. parsing stops with the error returned by fn.
+// lines can be arbitrarily long.
+//
+// city names expected to be in unicode letters, digits and underscores and can
+// optionally contain dashes, any other char separates the words in a line.
+// other names can be quoted with the Go string escapes, like "New York". a '#'
+// outside of a quoted name starts a comment until the end of the line.
+//
+// include directives are not supported, see ParseMapFS.
+//
+// TODO check `=` token to ensure that only `=` used to bind directions and cities
+// together. right now parser does not complain about what token is used.
+func ParseMapFunc(r io.Reader, fn func(*City) error) error {
+	return parseMap(r, func(city *City, _ int) error {
+		return fn(city)
+	}, func(_ include) error {
+		return errors.New("include directives can only be used with ParseMapFS")
+	}, nil)
+}
+
+// include is an include directive in a map defination.
+type include struct {
+	// path is the included file path relative to the including file.
+	path string
+
+	// region prefixes the names of the cities in the included file, if set.
+	region string
+
+	lineNumber int
+}
+
+// parseMap parses a map defination by reading from r line by line and calls
+// onCity with each city and the line number it is defined at. onInclude is
+// called with each include directive. onComments is called with the blocks of
+// comment lines that do not belong to a city, if it is set.
+func parseMap(r io.Reader, onCity func(city *City, lineNumber int) error, onInclude func(include) error,
+	onComments func([]string)) error {
+	br := bufio.NewReader(r)
+	var (
+		line       []byte
+		words      []word
+		lineNumber int
+
+		// comments are the comment lines right before the next city.
+		comments []string
+	)
+	detach := func() {
+		if len(comments) > 0 && onComments != nil {
+			onComments(comments)
+		}
+		comments = nil
+	}
 	for {
 		lineNumber++
-		line, _, err := lr.ReadLine()
-		if err == io.EOF {
-			return mp, nil
+		var err error
+		line, err = readLine(br, line[:0])
+		if err == io.EOF && len(line) == 0 {
+			detach()
+			return nil
 		}
-		if err != nil {
-			return mp, err
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if inc, ok, err := parseInclude(line, lineNumber); ok {
+			if err != nil {
+				return err
+			}
+			detach()
+			if err := onInclude(inc); err != nil {
+				return err
+			}
+			continue
+		}
+		var (
+			comment int
+			ok      bool
+		)
+		words, comment, ok = splitWords(line, words[:0])
+		if !ok {
+			return &InvalidNameError{lineNumber}
 		}
-		words := cityRe.FindAllString(string(line), -1)
 		lw := len(words)
 		if lw == 0 {
-			// allow empty lines in the map defination.
+			if comment >= 0 {
+				comments = append(comments, commentText(line[comment+1:]))
+				continue
+			}
+			// allow empty lines in the map defination. comments separated from
+			// the cities with empty lines do not belong to them.
+			detach()
 			continue
 		}
 		if lw < 3 || lw%2 != 1 {
 			// one of the city name, neighbor or direction information to a
 			// neighbor is missing.
-			return mp, &CityDefinitionError{lineNumber}
+			return &CityDefinitionError{lineNumber}
+		}
+		// unquoted names share the memory of the line with a single allocation.
+		text := string(line[words[0].start:words[lw-1].end])
+		offset := words[0].start
+		wordAt := func(i int) (string, error) {
+			w := text[words[i].start-offset : words[i].end-offset]
+			if !words[i].quoted {
+				return w, nil
+			}
+			name, err := strconv.Unquote(w)
+			if err != nil || name == "" {
+				return "", &InvalidNameError{lineNumber}
+			}
+			return name, nil
+		}
+		name, err := wordAt(0)
+		if err != nil {
+			return err
 		}
 		city := &City{
-			Name:      words[0],
-			Neighbors: make(map[compass.Direction]string),
+			Name:      name,
+			Neighbors: make(map[compass.Direction]string, (lw-1)/2),
+			Comments:  comments,
+		}
+		comments = nil
+		if comment >= 0 {
+			city.TrailingComment = commentText(line[comment+1:])
 		}
 		for i := 1; i < lw-1; i += 2 {
-			directionStr := words[i]
-			cityName := words[i+1]
-			direction, ok := compass.ParseDirection(directionStr)
+			directionStr, err := wordAt(i)
+			if err != nil {
+				return err
+			}
+			direction, ok := parseDirection(directionStr)
 			if !ok {
-				return mp, &InvalidDirectionError{lineNumber, directionStr}
+				return &InvalidDirectionError{lineNumber, directionStr}
 			}
-			city.Neighbors[direction] = cityName
+			neighborName, err := wordAt(i + 1)
+			if err != nil {
+				return err
+			}
+			city.Neighbors[direction] = neighborName
+		}
+		if err := onCity(city, lineNumber); err != nil {
+			return err
 		}
-		mp[city.Name] = city
 	}
 }
 
+// readLine appends the next line read from br to line without the line ending.
+// err is io.EOF when the last line has no line ending.
+func readLine(br *bufio.Reader, line []byte) ([]byte, error) {
+	for {
+		chunk, err := br.ReadSlice('\n')
+		line = append(line, chunk...)
+		if err == bufio.ErrBufferFull {
+			// the line is longer than the buffer, keep reading the rest of it.
+			continue
+		}
+		if n := len(line); n > 0 && line[n-1] == '\n' {
+			line = line[:n-1]
+			if n > 1 && line[n-2] == '\r' {
+				line = line[:n-2]
+			}
+		}
+		return line, err
+	}
+}
+
+// commentText returns the text of a comment without the trailing spaces.
+func commentText(comment []byte) string {
+	return string(bytes.TrimRight(comment, " \t\r"))
+}
+
+// word is the position of a word in a line.
+type word struct {
+	start, end int
+
+	// quoted is true when the word is a quoted name with its quotes.
+	quoted bool
+}
+
+// splitWords appends the words in line to words. words are the runs of the
+// chars allowed in city names and directions, or the quoted names. comment is
+// the position of the '#' that starts a comment, or -1 when there is no
+// comment. ok is false when a quoted name is not terminated.
+func splitWords(line []byte, words []word) (_ []word, comment int, ok bool) {
+	start := -1
+	for i := 0; i < len(line); {
+		r, size := rune(line[i]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRune(line[i:])
+		}
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			i += size
+			continue
+		}
+		if start >= 0 {
+			words = append(words, word{start: start, end: i})
+			start = -1
+		}
+		switch r {
+		case '#':
+			return words, i, true
+		case '"':
+			end := quoteEnd(line, i)
+			if end < 0 {
+				return words, -1, false
+			}
+			words = append(words, word{start: i, end: end, quoted: true})
+			i = end
+			continue
+		}
+		i += size
+	}
+	if start >= 0 {
+		words = append(words, word{start: start, end: len(line)})
+	}
+	return words, -1, true
+}
+
+// quoteEnd returns the position right after the closing quote of the quoted
+// name that starts at start, or -1 if it is not terminated.
+func quoteEnd(line []byte, start int) int {
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// isWordRune checks if r is allowed in city names and directions.
+func isWordRune(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+		return true
+	case r < utf8.RuneSelf:
+		return false
+	}
+	return unicode.IsLetter(r)
+}
+
+// parseDirection parses a direction like compass.ParseDirection but without
+// allocating.
+func parseDirection(s string) (direction compass.Direction, ok bool) {
+	for _, direction := range compass.Directions {
+		if strings.EqualFold(s, string(direction)) {
+			return direction, true
+		}
+	}
+	return "", false
+}
+
 // CraftMap makes an analysis on the game map to check map integrity like
 // determining impossible neighbor cities (a TODO).
 //
@@ -125,17 +371,15 @@ func CraftMap(mp Map) error {
 }
 
 // PrintMap prints a map to w and sorts the cities and directions alphabetically.
+// names are quoted when needed and the comments of the cities are kept.
 func PrintMap(w io.Writer, mp Map) error {
 	bw := bufio.NewWriter(w)
-	// sort city names.
-	var cityNames []string
-	for cityName := range mp {
-		cityNames = append(cityNames, cityName)
-	}
-	sort.Strings(cityNames)
-	for _, cityName := range cityNames {
+	for _, cityName := range mp.cityNames() {
 		city := mp[cityName]
-		bw.WriteString(cityName)
+		for _, comment := range city.Comments {
+			fmt.Fprintf(bw, "#%s\n", comment)
+		}
+		bw.WriteString(quoteName(cityName))
 		// sort directions.
 		var directions []string
 		for direction := range city.Neighbors {
@@ -145,7 +389,10 @@ func PrintMap(w io.Writer, mp Map) error {
 		for _, direction := range directions {
 			neighboor := city.Neighbors[compass.Direction(direction)]
 			direction := strings.ToLower(direction)
-			fmt.Fprintf(bw, " %s=%s", direction, neighboor)
+			fmt.Fprintf(bw, " %s=%s", direction, quoteName(neighboor))
+		}
+		if city.TrailingComment != "" {
+			fmt.Fprintf(bw, " #%s", city.TrailingComment)
 		}
 		bw.WriteString("\n")
 		if err := bw.Flush(); err != nil {
@@ -155,6 +402,30 @@ func PrintMap(w io.Writer, mp Map) error {
 	return nil
 }
 
+// quoteName quotes a city name for the map defination format if it has chars
+// other than unicode letters, digits, underscores and dashes.
+func quoteName(name string) string {
+	if name == "" {
+		return `""`
+	}
+	for _, r := range name {
+		if !isWordRune(r) {
+			return strconv.Quote(name)
+		}
+	}
+	return name
+}
+
+// cityNames returns the names of all cities in the map sorted alphabetically.
+func (mp Map) cityNames() []string {
+	var names []string
+	for name := range mp {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // CityDefinitionError is returned when a city defination in a row is not valid.
 type CityDefinitionError struct {
 	// LineNumber where error is found.
@@ -178,3 +449,14 @@ type InvalidDirectionError struct {
 func (e *InvalidDirectionError) Error() string {
 	return fmt.Sprintf("invalid direction %q found at line '%d'", e.Name, e.LineNumber)
 }
+
+// InvalidNameError is returned when a quoted name is not terminated, empty or
+// has invalid escapes.
+type InvalidNameError struct {
+	// LineNumber where error is found.
+	LineNumber int
+}
+
+func (e *InvalidNameError) Error() string {
+	return fmt.Sprintf("invalid quoted name found at line '%d'", e.LineNumber)
+}
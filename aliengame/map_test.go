@@ -3,6 +3,8 @@ package aliengame
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -42,7 +44,74 @@ Bar south=Foo west=Bee
 			},
 			nil,
 		},
-		// TODO test more branches.
+		{
+			"crlf line endings and unicode names",
+			"Şehir north=Köy\r\n\r\nKöy_2 SOUTH=Şehir",
+			Map{
+				"Şehir": &City{
+					Name:      "Şehir",
+					Neighbors: map[compass.Direction]string{compass.North: "Köy"},
+				},
+				"Köy_2": &City{
+					Name:      "Köy_2",
+					Neighbors: map[compass.Direction]string{compass.South: "Şehir"},
+				},
+			},
+			nil,
+		},
+		{
+			"comments and quoted names",
+			`# the big apple
+"New York" north="St. Louis" west=Foo # trailing "comment"
+# not kept
+
+"St. \"Louis\"#2" south="New York"`,
+			Map{
+				"New York": &City{
+					Name: "New York",
+					Neighbors: map[compass.Direction]string{
+						compass.North: "St. Louis",
+						compass.West:  "Foo",
+					},
+					Comments:        []string{" the big apple"},
+					TrailingComment: ` trailing "comment"`,
+				},
+				`St. "Louis"#2`: &City{
+					Name:      `St. "Louis"#2`,
+					Neighbors: map[compass.Direction]string{compass.South: "New York"},
+				},
+			},
+			nil,
+		},
+		{
+			"an unterminated quoted name",
+			`Foo north="Bar`,
+			Map{},
+			&InvalidNameError{1},
+		},
+		{
+			"an empty quoted name",
+			`Foo north=""`,
+			Map{},
+			&InvalidNameError{1},
+		},
+		{
+			"a missing neighbor",
+			"Foo north=Bar\nBar south=",
+			Map{
+				"Foo": &City{
+					Name:      "Foo",
+					Neighbors: map[compass.Direction]string{compass.North: "Bar"},
+				},
+			},
+			&CityDefinitionError{2},
+		},
+		{
+			"an invalid direction",
+			"\nFoo up=Bar",
+			Map{},
+			&InvalidDirectionError{2, "up"},
+		},
 	}
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -53,6 +122,119 @@ Bar south=Foo west=Bee
 	}
 }
 
+func TestParseMapFunc(t *testing.T) {
+	// a line longer than the read buffer is not split.
+	name := strings.Repeat("Foo", 100000)
+	mapdef := fmt.Sprintf("%s north=Bar west=Baz\nBar south=%s", name, name)
+	var cities []*City
+	err := ParseMapFunc(strings.NewReader(mapdef), func(city *City) error {
+		cities = append(cities, city)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*City{
+		{Name: name, Neighbors: map[compass.Direction]string{compass.North: "Bar", compass.West: "Baz"}},
+		{Name: "Bar", Neighbors: map[compass.Direction]string{compass.South: name}},
+	}, cities)
+
+	// parsing stops with the error of the callback.
+	errStop := errors.New("stop")
+	var count int
+	err = ParseMapFunc(strings.NewReader(mapdef), func(city *City) error {
+		count++
+		return errStop
+	})
+	require.Equal(t, errStop, err)
+	require.Equal(t, 1, count)
+}
+
+// mapSeeds are the seed map definations of the fuzz tests.
+var mapSeeds = []string{
+	"",
+	"Foo north=Bar west=Baz south=Qu-ux\nBee south=Bar\nYee west=Bar\n",
+	"# header\n\n\"New York\" north=\"St. Louis\" # trailing\n",
+	"Foo north=Foo east=Bar\r\nBar west=Baz\n",
+	"Foo north=\"Bar\nFoo up=Bar\ninclude a.aliengame\n",
+}
+
+func FuzzParseMap(f *testing.F) {
+	for _, seed := range mapSeeds {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, mapdef []byte) {
+		mp, err := ParseMap(bytes.NewReader(mapdef))
+		if err != nil {
+			return
+		}
+		for name, city := range mp {
+			require.Equal(t, name, city.Name)
+			require.NotEmpty(t, name)
+			require.NotEmpty(t, city.Neighbors)
+			for _, neighborName := range city.Neighbors {
+				require.NotEmpty(t, neighborName)
+			}
+		}
+	})
+}
+
+func FuzzMapRoundTrip(f *testing.F) {
+	for _, seed := range mapSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, mapdef string) {
+		mp, err := ParseMap(strings.NewReader(mapdef))
+		if err != nil || CraftMap(mp) != nil {
+			return
+		}
+		var buf bytes.Buffer
+		require.NoError(t, PrintMap(&buf, mp))
+		parsed, err := ParseMap(&buf)
+		require.NoError(t, err)
+		require.Equal(t, mp, parsed)
+	})
+}
+
+// benchmarkMap returns a map defination of a grid of cities with the number
+// of lines.
+func benchmarkMap(lines int) []byte {
+	var buf bytes.Buffer
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(&buf, "City-%d east=City-%d south=City-%d\n", i, i+1, i+1000)
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkParseMapFunc(b *testing.B) {
+	for _, lines := range []int{1000, 1000000} {
+		mapdef := benchmarkMap(lines)
+		b.Run(fmt.Sprintf("%d lines", lines), func(b *testing.B) {
+			b.SetBytes(int64(len(mapdef)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				err := ParseMapFunc(bytes.NewReader(mapdef), func(*City) error { return nil })
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseMap(b *testing.B) {
+	for _, lines := range []int{1000, 1000000} {
+		mapdef := benchmarkMap(lines)
+		b.Run(fmt.Sprintf("%d lines", lines), func(b *testing.B) {
+			b.SetBytes(int64(len(mapdef)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseMap(bytes.NewReader(mapdef)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 func TestPrintMap(t *testing.T) {
 	mapdef := `
 Foo north=Bar west=Baz south=Qu-ux
@@ -66,6 +248,32 @@ Yee west=Bar
 	require.Equal(t, "Foo north=Bar south=Qu-ux west=Baz\nYee west=Bar\n", buf.String())
 }
 
+func TestPrintMapRoundTrip(t *testing.T) {
+	mapdef := `# cities of the east coast
+# with a long history
+"New York" north=Boston south="St. Louis" # the big apple
+Boston south="New York"
+"St. Louis" north="New York" west="\"Quoted\" City"
+`
+	mp, err := ParseMap(strings.NewReader(mapdef))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	var buf bytes.Buffer
+	require.NoError(t, PrintMap(&buf, mp))
+	require.Equal(t, `"\"Quoted\" City" east="St. Louis"
+Boston south="New York"
+# cities of the east coast
+# with a long history
+"New York" north=Boston south="St. Louis" # the big apple
+"St. Louis" north="New York" west="\"Quoted\" City"
+`, buf.String())
+
+	// printed map is parsed to the same map.
+	parsed, err := ParseMap(&buf)
+	require.NoError(t, err)
+	require.Equal(t, mp, parsed)
+}
+
 func TestCraftMap(t *testing.T) {
 	mp := Map{
 		"Foo": &City{
@@ -123,3 +331,26 @@ func TestCraftMap(t *testing.T) {
 	mpjson, _ := json.Marshal(mp)
 	require.Equal(t, mpcraftedjson, mpjson)
 }
+
+func TestCloneMap(t *testing.T) {
+	mp := Map{
+		"Foo": &City{
+			Name: "Foo",
+			Neighbors: map[compass.Direction]string{
+				compass.North: "Bar",
+			},
+		},
+		"Bar": &City{
+			Name:           "Bar",
+			HasNoNeighbors: true,
+		},
+	}
+	clone := mp.Clone()
+	require.Equal(t, mp, clone)
+	clone["Foo"].Neighbors[compass.South] = "Baz"
+	clone["Bar"].HasNoNeighbors = false
+	delete(clone, "Foo")
+	require.Len(t, mp, 2)
+	require.Len(t, mp["Foo"].Neighbors, 1)
+	require.True(t, mp["Bar"].HasNoNeighbors)
+}
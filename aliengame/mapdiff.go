@@ -0,0 +1,243 @@
+package aliengame
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// MapDiff is the difference between two maps.
+type MapDiff struct {
+	// AddedCities and RemovedCities are the names of the cities that only
+	// exist in the new or the old map, sorted alphabetically.
+	AddedCities   []string `json:"addedCities"`
+	RemovedCities []string `json:"removedCities"`
+
+	// AddedLinks and RemovedLinks are the neighbor links that only exist in
+	// the new or the old map, including the links of the added and removed
+	// cities. a changed link is both removed and added.
+	AddedLinks   []Link `json:"addedLinks"`
+	RemovedLinks []Link `json:"removedLinks"`
+}
+
+// Link is a neighbor link of a city.
+type Link struct {
+	City      string            `json:"city"`
+	Direction compass.Direction `json:"direction"`
+	Neighbor  string            `json:"neighbor"`
+}
+
+// String formats the link as in the map defination format.
+func (l Link) String() string {
+	return fmt.Sprintf("%s %s=%s", quoteName(l.City), strings.ToLower(string(l.Direction)), quoteName(l.Neighbor))
+}
+
+// Empty checks if there is no difference.
+func (d MapDiff) Empty() bool {
+	return len(d.AddedCities) == 0 && len(d.RemovedCities) == 0 &&
+		len(d.AddedLinks) == 0 && len(d.RemovedLinks) == 0
+}
+
+// DiffMaps finds out the difference from map a to map b. diffing the initial
+// map of a world with its current map shows what the aliens have destroyed.
+func DiffMaps(a, b Map) MapDiff {
+	var d MapDiff
+	for _, name := range a.cityNames() {
+		if _, ok := b[name]; !ok {
+			d.RemovedCities = append(d.RemovedCities, name)
+		}
+	}
+	for _, name := range b.cityNames() {
+		if _, ok := a[name]; !ok {
+			d.AddedCities = append(d.AddedCities, name)
+		}
+	}
+	d.RemovedLinks = linksNotIn(a, b)
+	d.AddedLinks = linksNotIn(b, a)
+	return d
+}
+
+// linksNotIn returns the links of a that are not in b, sorted by city names
+// and directions.
+func linksNotIn(a, b Map) []Link {
+	var links []Link
+	for _, name := range a.cityNames() {
+		for _, direction := range compass.Directions {
+			neighborName, ok := a[name].Neighbors[direction]
+			if !ok {
+				continue
+			}
+			if city, ok := b[name]; ok && city.Neighbors[direction] == neighborName {
+				continue
+			}
+			links = append(links, Link{name, direction, neighborName})
+		}
+	}
+	return links
+}
+
+// PrintMapDiff prints the diff to w. removed cities and links are prefixed
+// with "-" and the added ones with "+".
+func PrintMapDiff(w io.Writer, d MapDiff) error {
+	bw := bufio.NewWriter(w)
+	for _, name := range d.RemovedCities {
+		fmt.Fprintf(bw, "- %s\n", name)
+	}
+	for _, name := range d.AddedCities {
+		fmt.Fprintf(bw, "+ %s\n", name)
+	}
+	for _, link := range d.RemovedLinks {
+		fmt.Fprintf(bw, "- %s\n", link)
+	}
+	for _, link := range d.AddedLinks {
+		fmt.Fprintf(bw, "+ %s\n", link)
+	}
+	return bw.Flush()
+}
+
+// MergeConflictError is returned when the changes made on the same base map
+// cannot be merged.
+type MergeConflictError struct {
+	// Conflicts explain the conflicting changes.
+	Conflicts []string
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("%d merge conflicts: %s", len(e.Conflicts), strings.Join(e.Conflicts, "; "))
+}
+
+// MergeMaps makes a three-way merge of the maps ours and theirs that are both
+// edited from the base map. a change made only by one side is taken as it is.
+// error is a *MergeConflictError when both sides change the same neighbor link
+// differently, or the merged map has links from or to removed cities.
+// comments of a city are taken from the side that changed them, from ours when
+// both sides did.
+func MergeMaps(base, ours, theirs Map) (Map, error) {
+	names := make(map[string]bool)
+	for _, mp := range []Map{base, ours, theirs} {
+		for name := range mp {
+			names[name] = true
+		}
+	}
+	var sortedNames []string
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	var conflicts []string
+	merged := make(Map)
+	for _, name := range sortedNames {
+		_, inBase := base[name]
+		_, inOurs := ours[name]
+		_, inTheirs := theirs[name]
+		// ours and theirs cannot be different from each other and also from
+		// base at the same time.
+		exists := inOurs
+		if inOurs == inBase {
+			exists = inTheirs
+		}
+		city := &City{Name: name, Neighbors: make(map[compass.Direction]string)}
+		for _, direction := range compass.Directions {
+			baseLink := neighborOf(base, name, direction)
+			ourLink := neighborOf(ours, name, direction)
+			theirLink := neighborOf(theirs, name, direction)
+			link := ourLink
+			switch {
+			case ourLink == theirLink, theirLink == baseLink:
+			case ourLink == baseLink:
+				link = theirLink
+			default:
+				conflicts = append(conflicts, fmt.Sprintf("%s %s: ours %s, theirs %s", name,
+					strings.ToLower(string(direction)), formatNeighbor(ourLink), formatNeighbor(theirLink)))
+				continue
+			}
+			if link != "" {
+				city.Neighbors[direction] = link
+			}
+		}
+		if exists {
+			if source := commentSource(base[name], ours[name], theirs[name]); source != nil {
+				city.Comments = append([]string(nil), source.Comments...)
+				city.TrailingComment = source.TrailingComment
+			}
+			merged[name] = city
+			continue
+		}
+		for _, direction := range compass.Directions {
+			if neighborName, ok := city.Neighbors[direction]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("%s %s: removed city is linked to %s", name,
+					strings.ToLower(string(direction)), neighborName))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &MergeConflictError{conflicts}
+	}
+	// check if the links of the cities changed by different sides still point
+	// to each other.
+	for _, name := range merged.cityNames() {
+		for _, direction := range compass.Directions {
+			neighborName, ok := merged[name].Neighbors[direction]
+			if !ok {
+				continue
+			}
+			neighbor, ok := merged[neighborName]
+			switch {
+			case !ok && names[neighborName]:
+				conflicts = append(conflicts, fmt.Sprintf("%s %s: linked to removed city %s", name,
+					strings.ToLower(string(direction)), neighborName))
+			case ok && neighbor.Neighbors[compass.ReverseDirection(direction)] != name:
+				conflicts = append(conflicts, fmt.Sprintf("%s %s: %s does not link back", name,
+					strings.ToLower(string(direction)), neighborName))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &MergeConflictError{conflicts}
+	}
+	return merged, nil
+}
+
+// commentSource returns the side to take the comments of a city from for
+// MergeMaps. ours is taken when it changed the comments, otherwise theirs.
+func commentSource(base, ours, theirs *City) *City {
+	if ours != nil && (base == nil || !sameComments(base, ours)) || theirs == nil {
+		return ours
+	}
+	return theirs
+}
+
+// sameComments checks if the cities have the same comments.
+func sameComments(a, b *City) bool {
+	if a.TrailingComment != b.TrailingComment || len(a.Comments) != len(b.Comments) {
+		return false
+	}
+	for i := range a.Comments {
+		if a.Comments[i] != b.Comments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// neighborOf returns the neighbor of the city in the direction, it is empty
+// when there is no such city or neighbor.
+func neighborOf(mp Map, name string, direction compass.Direction) string {
+	if city, ok := mp[name]; ok {
+		return city.Neighbors[direction]
+	}
+	return ""
+}
+
+// formatNeighbor formats a neighbor name for merge conflicts.
+func formatNeighbor(name string) string {
+	if name == "" {
+		return "none"
+	}
+	return name
+}
@@ -0,0 +1,133 @@
+package aliengame
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+// parseTestMap parses and crafts a map from the lines of its defination.
+func parseTestMap(t *testing.T, lines ...string) Map {
+	mp, err := ParseMap(strings.NewReader(strings.Join(lines, "\n")))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	return mp
+}
+
+func TestDiffMaps(t *testing.T) {
+	a := parseTestMap(t, "Foo north=Bar west=Baz")
+	b := parseTestMap(t, "Foo north=Bar east=Baz", "Bar north=Qux")
+
+	d := DiffMaps(a, b)
+	require.Equal(t, MapDiff{
+		AddedCities: []string{"Qux"},
+		RemovedLinks: []Link{
+			{"Baz", compass.East, "Foo"},
+			{"Foo", compass.West, "Baz"},
+		},
+		AddedLinks: []Link{
+			{"Bar", compass.North, "Qux"},
+			{"Baz", compass.West, "Foo"},
+			{"Foo", compass.East, "Baz"},
+			{"Qux", compass.South, "Bar"},
+		},
+	}, d)
+	require.False(t, d.Empty())
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintMapDiff(&buf, d))
+	require.Equal(t, `+ Qux
+- Baz east=Foo
+- Foo west=Baz
++ Bar north=Qux
++ Baz west=Foo
++ Foo east=Baz
++ Qux south=Bar
+`, buf.String())
+
+	require.True(t, DiffMaps(a, a.Clone()).Empty())
+}
+
+func TestDiffMapsDestroyed(t *testing.T) {
+	mp := parseTestMap(t, "Foo north=Bar west=Baz")
+	world := New(mp.Clone(), nil, WithSeed(1))
+	require.NoError(t, world.SpawnAlienAt("A1", "Bar"))
+	require.NoError(t, world.SpawnAlienAt("A2", "Baz"))
+	world.Resume()
+
+	// both aliens move to Foo and destroy it.
+	d := DiffMaps(mp, world.Map())
+	require.Equal(t, []string{"Foo"}, d.RemovedCities)
+	require.Empty(t, d.AddedCities)
+	require.Empty(t, d.AddedLinks)
+	require.Equal(t, []Link{
+		{"Bar", compass.South, "Foo"},
+		{"Baz", compass.East, "Foo"},
+		{"Foo", compass.North, "Bar"},
+		{"Foo", compass.West, "Baz"},
+	}, d.RemovedLinks)
+}
+
+func TestMergeMaps(t *testing.T) {
+	base := parseTestMap(t, "Foo north=Bar west=Baz")
+
+	// ours adds Qux to the east of Foo, theirs removes Baz and adds Yee to the
+	// north of Bar.
+	ours := base.Clone()
+	require.NoError(t, ours.AddCity("Qux"))
+	require.NoError(t, ours.Connect("Foo", compass.East, "Qux"))
+	theirs := base.Clone()
+	require.NoError(t, theirs.RemoveCity("Baz"))
+	require.NoError(t, theirs.AddCity("Yee"))
+	require.NoError(t, theirs.Connect("Bar", compass.North, "Yee"))
+
+	merged, err := MergeMaps(base, ours, theirs)
+	require.NoError(t, err)
+	require.Equal(t, parseTestMap(t, "Foo north=Bar east=Qux", "Bar north=Yee"), merged)
+
+	// both sides link the east of Foo to different cities.
+	theirs = base.Clone()
+	require.NoError(t, theirs.AddCity("Yee"))
+	require.NoError(t, theirs.Connect("Foo", compass.East, "Yee"))
+	_, err = MergeMaps(base, ours, theirs)
+	require.Equal(t, &MergeConflictError{[]string{
+		"Foo east: ours Qux, theirs Yee",
+	}}, err)
+
+	// ours links to a city removed by theirs.
+	ours = base.Clone()
+	require.NoError(t, ours.Connect("Bar", compass.East, "Baz"))
+	theirs = base.Clone()
+	require.NoError(t, theirs.RemoveCity("Baz"))
+	_, err = MergeMaps(base, ours, theirs)
+	require.Equal(t, &MergeConflictError{[]string{
+		"Baz west: removed city is linked to Bar",
+	}}, err)
+
+	// theirs links to a city removed by ours.
+	ours = base.Clone()
+	require.NoError(t, ours.RemoveCity("Baz"))
+	theirs = base.Clone()
+	require.NoError(t, theirs.Disconnect("Baz", compass.East))
+	require.NoError(t, theirs.AddCity("Qux"))
+	require.NoError(t, theirs.Connect("Qux", compass.North, "Baz"))
+	_, err = MergeMaps(base, ours, theirs)
+	require.Equal(t, &MergeConflictError{[]string{
+		"Baz south: removed city is linked to Qux",
+	}}, err)
+
+	// comments are taken from the side that changed them.
+	base["Foo"].Comments = []string{" foo"}
+	ours = base.Clone()
+	ours["Foo"].TrailingComment = " ours"
+	theirs = base.Clone()
+	theirs["Bar"].Comments = []string{" theirs"}
+	merged, err = MergeMaps(base, ours, theirs)
+	require.NoError(t, err)
+	require.Equal(t, []string{" foo"}, merged["Foo"].Comments)
+	require.Equal(t, " ours", merged["Foo"].TrailingComment)
+	require.Equal(t, []string{" theirs"}, merged["Bar"].Comments)
+}
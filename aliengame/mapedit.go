@@ -0,0 +1,116 @@
+package aliengame
+
+import (
+	"fmt"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// AddCity adds a new city without any roads to the map.
+func (mp Map) AddCity(name string) error {
+	if name == "" {
+		return fmt.Errorf("city name cannot be empty")
+	}
+	if _, ok := mp[name]; ok {
+		return fmt.Errorf("city %q already exists", name)
+	}
+	mp[name] = &City{
+		Name:      name,
+		Neighbors: make(map[compass.Direction]string),
+	}
+	return nil
+}
+
+// RemoveCity removes the city and all the roads to it from the map.
+func (mp Map) RemoveCity(name string) error {
+	city, ok := mp[name]
+	if !ok {
+		return fmt.Errorf("city %q does not exist", name)
+	}
+	for direction, neighborName := range city.Neighbors {
+		if neighbor, ok := mp[neighborName]; ok {
+			neighbor.disconnect(compass.ReverseDirection(direction), name)
+		}
+	}
+	delete(mp, name)
+	return nil
+}
+
+// Connect builds a road from city a to city b in the direction, and the road
+// back from b to a in the reverse direction. both cities must exist and the
+// directions must not be used by other roads. directions are case insensitive.
+func (mp Map) Connect(a string, direction compass.Direction, b string) error {
+	direction, err := mp.checkConnect(a, direction, b)
+	if err != nil {
+		return err
+	}
+	cityA, cityB := mp[a], mp[b]
+	for _, city := range []*City{cityA, cityB} {
+		if city.Neighbors == nil {
+			city.Neighbors = make(map[compass.Direction]string)
+		}
+	}
+	cityA.Neighbors[direction] = b
+	cityB.Neighbors[compass.ReverseDirection(direction)] = a
+	return nil
+}
+
+// checkConnect checks if city a can be connected to city b in the direction,
+// and returns the canonical form of the direction.
+func (mp Map) checkConnect(a string, direction compass.Direction, b string) (compass.Direction, error) {
+	parsed, ok := compass.ParseDirection(string(direction))
+	if !ok {
+		return "", fmt.Errorf("invalid direction %q", direction)
+	}
+	direction = parsed
+	if a == b {
+		return "", fmt.Errorf("city %q cannot be connected to itself", a)
+	}
+	cityA, ok := mp[a]
+	if !ok {
+		return "", fmt.Errorf("city %q does not exist", a)
+	}
+	cityB, ok := mp[b]
+	if !ok {
+		return "", fmt.Errorf("city %q does not exist", b)
+	}
+	revDirection := compass.ReverseDirection(direction)
+	if neighbor, ok := cityA.Neighbors[direction]; ok && neighbor != b {
+		return "", fmt.Errorf("%s of %q is already connected to %q", direction, a, neighbor)
+	}
+	if neighbor, ok := cityB.Neighbors[revDirection]; ok && neighbor != a {
+		return "", fmt.Errorf("%s of %q is already connected to %q", revDirection, b, neighbor)
+	}
+	return direction, nil
+}
+
+// Disconnect removes the road of city a in the direction, and the road back to
+// a from the neighbor. the direction is case insensitive.
+func (mp Map) Disconnect(a string, direction compass.Direction) error {
+	parsed, ok := compass.ParseDirection(string(direction))
+	if !ok {
+		return fmt.Errorf("invalid direction %q", direction)
+	}
+	direction = parsed
+	city, ok := mp[a]
+	if !ok {
+		return fmt.Errorf("city %q does not exist", a)
+	}
+	neighborName, ok := city.Neighbors[direction]
+	if !ok {
+		return fmt.Errorf("there is no road to %s of %q", direction, a)
+	}
+	delete(city.Neighbors, direction)
+	if neighbor, ok := mp[neighborName]; ok {
+		neighbor.disconnect(compass.ReverseDirection(direction), a)
+	}
+	return nil
+}
+
+// disconnect removes the road of the city in the direction if it leads to
+// neighborName. roads of crafted maps may lead to other cities.
+func (c *City) disconnect(direction compass.Direction, neighborName string) {
+	if c.Neighbors[direction] == neighborName {
+		delete(c.Neighbors, direction)
+	}
+}
@@ -0,0 +1,66 @@
+package aliengame
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditMap(t *testing.T) {
+	mp := make(Map)
+	require.NoError(t, mp.AddCity("Foo"))
+	require.NoError(t, mp.AddCity("Bar"))
+	require.NoError(t, mp.AddCity("Baz"))
+	require.Error(t, mp.AddCity("Foo"))
+	require.Error(t, mp.AddCity(""))
+
+	require.NoError(t, mp.Connect("Foo", compass.North, "Bar"))
+	require.NoError(t, mp.Connect("Foo", compass.North, "Bar"))
+	require.NoError(t, mp.Connect("Foo", compass.Direction("north"), "Bar"))
+	require.NoError(t, mp.Connect("Baz", compass.East, "Foo"))
+	require.Error(t, mp.Connect("Foo", compass.North, "Baz"))
+	require.Error(t, mp.Connect("Bar", compass.West, "Baz"), "west of Bar is free but east of Baz is not")
+	require.Error(t, mp.Connect("Foo", compass.Direction("Up"), "Baz"))
+	require.Error(t, mp.Connect("Foo", compass.South, "Foo"))
+	require.Error(t, mp.Connect("Foo", compass.South, "Qux"))
+	require.Error(t, mp.Connect("Qux", compass.South, "Foo"))
+
+	var buf bytes.Buffer
+	require.NoError(t, PrintMap(&buf, mp))
+	require.Equal(t, `Bar south=Foo
+Baz east=Foo
+Foo north=Bar west=Baz
+`, buf.String())
+
+	require.NoError(t, mp.Disconnect("Bar", compass.Direction("south")))
+	require.Error(t, mp.Disconnect("Bar", compass.South))
+	require.Error(t, mp.Disconnect("Qux", compass.South))
+	require.Error(t, mp.Disconnect("Bar", compass.Direction("Up")))
+	require.Empty(t, mp["Bar"].Neighbors)
+	require.Equal(t, map[compass.Direction]string{compass.West: "Baz"}, mp["Foo"].Neighbors)
+
+	require.NoError(t, mp.RemoveCity("Foo"))
+	require.Error(t, mp.RemoveCity("Foo"))
+	require.Empty(t, mp["Baz"].Neighbors)
+	require.Len(t, mp, 2)
+
+	// roads back that lead to other cities are kept. CraftMap links Bar back
+	// to one of Foo and Baz, the other one is removed.
+	for _, edit := range []func(mp Map, name string) error{
+		func(mp Map, name string) error { return mp.RemoveCity(name) },
+		func(mp Map, name string) error { return mp.Disconnect(name, compass.North) },
+	} {
+		mp, err := ParseMap(strings.NewReader("Foo north=Bar\nBaz north=Bar"))
+		require.NoError(t, err)
+		require.NoError(t, CraftMap(mp))
+		kept, removed := "Baz", "Foo"
+		if mp["Bar"].Neighbors[compass.South] == "Foo" {
+			kept, removed = removed, kept
+		}
+		require.NoError(t, edit(mp, removed))
+		require.Equal(t, map[compass.Direction]string{compass.South: kept}, mp["Bar"].Neighbors)
+	}
+}
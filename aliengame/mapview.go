@@ -0,0 +1,57 @@
+package aliengame
+
+import "github.com/ilgooz/aliengame/x/compass"
+
+// MapView is a read-only view of a game map. all accessors return copies so
+// the underlying map cannot be modified through the view.
+type MapView struct {
+	mp Map
+}
+
+// View returns a read-only view of the map. the view reflects the later
+// changes made to mp, use mp.Clone().View() to get an immutable view.
+func (mp Map) View() MapView {
+	return MapView{mp}
+}
+
+// Len returns the number of cities in the map.
+func (v MapView) Len() int {
+	return len(v.mp)
+}
+
+// CityNames returns the names of all cities in the map sorted alphabetically.
+func (v MapView) CityNames() []string {
+	return v.mp.cityNames()
+}
+
+// HasCity checks if a city with the given name exists in the map.
+func (v MapView) HasCity(name string) bool {
+	_, ok := v.mp[name]
+	return ok
+}
+
+// City returns a copy of the city with the given name. ok is false when the city
+// does not exist in the map.
+func (v MapView) City(name string) (city *City, ok bool) {
+	c, ok := v.mp[name]
+	if !ok {
+		return nil, false
+	}
+	return c.Clone(), true
+}
+
+// Neighbor returns the name of the neighbor city of the given city in direction.
+// ok is false when the city or neighbor does not exist.
+func (v MapView) Neighbor(cityName string, direction compass.Direction) (neighborName string, ok bool) {
+	city, ok := v.mp[cityName]
+	if !ok {
+		return "", false
+	}
+	neighborName, ok = city.Neighbors[direction]
+	return neighborName, ok
+}
+
+// Map returns a copy of the underlying map.
+func (v MapView) Map() Map {
+	return v.mp.Clone()
+}
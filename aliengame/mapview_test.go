@@ -0,0 +1,41 @@
+package aliengame
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapView(t *testing.T) {
+	mp, err := ParseMap(strings.NewReader("Foo north=Bar west=Baz"))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	view := mp.View()
+
+	require.Equal(t, 3, view.Len())
+	require.Equal(t, []string{"Bar", "Baz", "Foo"}, view.CityNames())
+	require.True(t, view.HasCity("Foo"))
+	require.False(t, view.HasCity("Qux"))
+
+	neighborName, ok := view.Neighbor("Foo", compass.North)
+	require.True(t, ok)
+	require.Equal(t, "Bar", neighborName)
+	_, ok = view.Neighbor("Foo", compass.South)
+	require.False(t, ok)
+	_, ok = view.Neighbor("Qux", compass.South)
+	require.False(t, ok)
+
+	// modifying returned values does not modify the map.
+	city, ok := view.City("Foo")
+	require.True(t, ok)
+	delete(city.Neighbors, compass.North)
+	view.Map()["Foo"].Neighbors[compass.West] = "Qux"
+	require.Equal(t, map[compass.Direction]string{
+		compass.North: "Bar",
+		compass.West:  "Baz",
+	}, mp["Foo"].Neighbors)
+	_, ok = view.City("Qux")
+	require.False(t, ok)
+}
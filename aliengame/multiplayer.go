@@ -0,0 +1,247 @@
+package aliengame
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+const (
+	defaultTurnTimeout = 30 * time.Second
+
+	// cityDestroyedPoints is the points that a player earns for each city
+	// destroyed in the fights that their aliens joined.
+	cityDestroyedPoints = 10
+)
+
+// WithTurnTimeout sets the max duration that Resume waits for players to submit
+// their moves, 30s by default. aliens of the idle players move randomly. Resume
+// does not wait when d is zero.
+func WithTurnTimeout(d time.Duration) Option {
+	return func(w *World) {
+		w.turnTimeout = d
+	}
+}
+
+// control is the binding of an alien to the player controlling it.
+type control struct {
+	player string
+
+	// since is the iteration that player started to control the alien.
+	since int
+
+	// survived is the number of iterations that the alien survived when it is
+	// killed by KillAlien.
+	survived int
+}
+
+// Score is the score of a player.
+type Score struct {
+	// Player is the name of the player.
+	Player string `json:"player"`
+
+	// Aliens are the names of aliens controlled by the player.
+	Aliens []string `json:"aliens"`
+
+	// Survivors are the names of living aliens of the player.
+	Survivors []string `json:"survivors"`
+
+	// SurvivedIterations is the total number of iterations that aliens of the
+	// player survived while being controlled by the player.
+	SurvivedIterations int `json:"survivedIterations"`
+
+	// CitiesDestroyed is the number of cities destroyed in the fights that
+	// aliens of the player joined.
+	CitiesDestroyed int `json:"citiesDestroyed"`
+
+	// Points is SurvivedIterations plus 10 points for each destroyed city.
+	Points int `json:"points"`
+}
+
+// AddPlayer binds the living aliens to player. aliens of players do not move
+// randomly, they move to the directions submitted by their players with Move
+// unless players stay idle for the whole turn.
+func (w *World) AddPlayer(player string, alienNames ...string) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if player == "" {
+		return fmt.Errorf("player name cannot be empty")
+	}
+	if len(alienNames) == 0 {
+		return fmt.Errorf("player %q must control at least one alien", player)
+	}
+	if w.done {
+		return fmt.Errorf("world has ended")
+	}
+	for _, alienName := range alienNames {
+		if w.alien(alienName) == nil {
+			return fmt.Errorf("alien %q is not living", alienName)
+		}
+		if c, ok := w.controls[alienName]; ok {
+			return fmt.Errorf("alien %q is already controlled by player %q", alienName, c.player)
+		}
+	}
+	if w.controls == nil {
+		w.controls = make(map[string]control)
+	}
+	for _, alienName := range alienNames {
+		w.controls[alienName] = control{player: player, since: w.iteration}
+	}
+	return nil
+}
+
+// Move submits the intent of player to move their alien to the neighbor city
+// in direction at the next iteration. a submitted move can be changed by
+// submitting another one till the iteration starts.
+func (w *World) Move(player, alienName string, direction compass.Direction) error {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if w.done {
+		return fmt.Errorf("world has ended")
+	}
+	alien := w.alien(alienName)
+	if alien == nil {
+		return fmt.Errorf("alien %q is not living", alienName)
+	}
+	if c, ok := w.controls[alienName]; !ok || c.player != player {
+		return fmt.Errorf("alien %q is not controlled by player %q", alienName, player)
+	}
+	if !canAlienMove(alien) {
+		return fmt.Errorf("alien %q cannot move anymore", alienName)
+	}
+	if _, ok := w.mp[alien.CityName].Neighbors[direction]; !ok {
+		return fmt.Errorf("city %q has no neighbor at %s", alien.CityName, direction)
+	}
+	if w.intents == nil {
+		w.intents = make(map[string]compass.Direction)
+	}
+	w.intents[alienName] = direction
+	w.wakeUp()
+	return nil
+}
+
+// wakeUp wakes up the Resume waiting for the moves to check them again.
+func (w *World) wakeUp() {
+	if w.intentC != nil {
+		close(w.intentC)
+		w.intentC = nil
+	}
+}
+
+// Scores returns the scores of all players sorted by their points in
+// descending order, and then by their names.
+func (w *World) Scores() []Score {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	scores := make(map[string]*Score)
+	for alienName, c := range w.controls {
+		score, ok := scores[c.player]
+		if !ok {
+			score = &Score{Player: c.player}
+			scores[c.player] = score
+		}
+		score.Aliens = append(score.Aliens, alienName)
+		score.SurvivedIterations += c.survived
+		if w.alien(alienName) != nil {
+			score.Survivors = append(score.Survivors, alienName)
+			score.SurvivedIterations += w.iteration - c.since
+		}
+	}
+	for _, city := range w.destroyedCities {
+		players := make(map[string]bool)
+		for _, alienName := range city.Aliens {
+			c, ok := w.controls[alienName]
+			if !ok {
+				continue
+			}
+			// alien died in the iteration that city is destroyed, or after
+			// it when the city is destroyed from outside of the game.
+			if city.External {
+				scores[c.player].SurvivedIterations += city.Iteration - c.since
+				continue
+			}
+			scores[c.player].SurvivedIterations += city.Iteration - 1 - c.since
+			players[c.player] = true
+		}
+		for player := range players {
+			scores[player].CitiesDestroyed++
+		}
+	}
+	var list []Score
+	for _, score := range scores {
+		sort.Strings(score.Aliens)
+		sort.Strings(score.Survivors)
+		score.Points = score.SurvivedIterations + score.CitiesDestroyed*cityDestroyedPoints
+		list = append(list, *score)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Points != list[j].Points {
+			return list[i].Points > list[j].Points
+		}
+		return list[i].Player < list[j].Player
+	})
+	return list
+}
+
+// waitMoves waits till all players submit moves for their aliens or the turn
+// timeout is exceeded.
+func (w *World) waitMoves(ctx context.Context) error {
+	var timeout <-chan time.Time
+	for {
+		w.ma.Lock()
+		waiting := w.waitingMoves()
+		if waiting && w.intentC == nil {
+			w.intentC = make(chan struct{})
+		}
+		intentC := w.intentC
+		w.ma.Unlock()
+		if !waiting {
+			return nil
+		}
+		if timeout == nil {
+			timer := time.NewTimer(w.turnTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-intentC:
+		case <-timeout:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// waitingMoves checks if there are aliens that can move but their players have
+// not submitted a move yet.
+func (w *World) waitingMoves() bool {
+	if w.done {
+		return false
+	}
+	for _, alien := range w.aliens {
+		if _, ok := w.controls[alien.Name]; !ok {
+			continue
+		}
+		if _, ok := w.intents[alien.Name]; ok {
+			continue
+		}
+		if canAlienMove(alien) && len(w.mp[alien.CityName].Neighbors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// alien finds the living alien by its name.
+func (w *World) alien(name string) *Alien {
+	for _, alien := range w.aliens {
+		if alien.Name == name {
+			return alien
+		}
+	}
+	return nil
+}
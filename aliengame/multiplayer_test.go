@@ -0,0 +1,112 @@
+package aliengame
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+// newLineWorld creates a world with cities A, B and C from west to east and
+// spawns aliens at the cities with the given indexes.
+func newLineWorld(t *testing.T, cityIndexes []int, options ...Option) *World {
+	world := newTestWorld(t, "B west=A east=C", 0, nil, options...)
+	world.randIndex = func(int) int {
+		i := cityIndexes[0]
+		cityIndexes = cityIndexes[1:]
+		return i
+	}
+	world.SpawnAlien(len(cityIndexes))
+	return world
+}
+
+func TestAddPlayer(t *testing.T) {
+	world := newLineWorld(t, []int{0, 2})
+	require.NoError(t, world.AddPlayer("p1", "A2"))
+	require.Error(t, world.AddPlayer("", "A1"))
+	require.Error(t, world.AddPlayer("p2"))
+	require.Error(t, world.AddPlayer("p2", "A2"))
+	require.Error(t, world.AddPlayer("p2", "A3"))
+	require.NoError(t, world.AddPlayer("p2", "A1"))
+	world.End()
+	require.Error(t, world.AddPlayer("p3", "A1"))
+}
+
+func TestMove(t *testing.T) {
+	// A2 is in A and A1 is in C.
+	world := newLineWorld(t, []int{0, 2})
+	require.NoError(t, world.AddPlayer("p1", "A2"))
+	require.NoError(t, world.AddPlayer("p2", "A1"))
+
+	require.Error(t, world.Move("p2", "A2", compass.East))
+	require.Error(t, world.Move("p1", "A2", compass.West))
+	require.Error(t, world.Move("p1", "A3", compass.East))
+	require.NoError(t, world.Move("p1", "A2", compass.East))
+	require.NoError(t, world.Move("p2", "A1", compass.West))
+
+	// all moves are submitted so Resume does not wait for the turn timeout.
+	start := time.Now()
+	require.False(t, world.Resume())
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+	require.Equal(t, []DestroyedCity{{Name: "B", Iteration: 1, Aliens: []string{"A2", "A1"}}}, world.Stats().DestroyedCities)
+	require.Equal(t, []Score{
+		{Player: "p1", Aliens: []string{"A2"}, CitiesDestroyed: 1, Points: 10},
+		{Player: "p2", Aliens: []string{"A1"}, CitiesDestroyed: 1, Points: 10},
+	}, world.Scores())
+	require.Error(t, world.Move("p1", "A2", compass.East))
+}
+
+func TestMoveWakesResume(t *testing.T) {
+	world := newLineWorld(t, []int{1}, WithTurnTimeout(time.Minute))
+	require.NoError(t, world.AddPlayer("p1", "A1"))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		world.Move("p1", "A1", compass.East)
+	}()
+	require.True(t, world.Resume())
+	require.Equal(t, "C", world.Aliens()[0].CityName)
+	require.Equal(t, []Score{
+		{Player: "p1", Aliens: []string{"A1"}, Survivors: []string{"A1"}, SurvivedIterations: 1, Points: 1},
+	}, world.Scores())
+}
+
+func TestWaitStops(t *testing.T) {
+	// Run returns at its timeout while waiting for the player.
+	world := newLineWorld(t, []int{1}, WithTurnTimeout(time.Minute))
+	require.NoError(t, world.AddPlayer("p1", "A1"))
+	start := time.Now()
+	result, err := world.Run(context.Background(), RunOptions{Timeout: 10 * time.Millisecond})
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.Equal(t, RunResult{Cancelled, 0}, result)
+	require.Less(t, int64(time.Since(start)), int64(time.Minute))
+
+	// End stops the waiting Resume.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		world.End()
+	}()
+	require.False(t, world.Resume())
+	require.Equal(t, 0, world.Iteration())
+	require.False(t, world.Resume())
+}
+
+func TestTurnTimeout(t *testing.T) {
+	// idle player's alien moves randomly, to the east.
+	world := newLineWorld(t, []int{1}, WithTurnTimeout(10*time.Millisecond))
+	require.NoError(t, world.AddPlayer("p1", "A1"))
+	world.randIndex = func(int) int { return 0 }
+	start := time.Now()
+	require.True(t, world.Resume())
+	require.GreaterOrEqual(t, int64(time.Since(start)), int64(10*time.Millisecond))
+	require.Equal(t, "C", world.Aliens()[0].CityName)
+
+	// the move is only used once.
+	require.NoError(t, world.Move("p1", "A1", compass.West))
+	require.True(t, world.Resume())
+	require.Equal(t, "B", world.Aliens()[0].CityName)
+	require.True(t, world.Resume())
+	require.Equal(t, "C", world.Aliens()[0].CityName)
+}
@@ -0,0 +1,155 @@
+package aliengame
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RenderOptions configures RenderMap.
+type RenderOptions struct {
+	// Layout is the positions of cities on the grid. it defaults to the layout
+	// of the rendered map. the layout of an initial map can be used to render
+	// the later states of the map with the cities kept at the same positions,
+	// in that case cities that are in the layout but not in the map are drawn
+	// as destroyed.
+	Layout map[string]Point
+
+	// Aliens are drawn in their cities as alien counts. trapped aliens are
+	// marked with `!`.
+	Aliens []Alien
+
+	// ASCII draws with plain ASCII chars instead of the Unicode box-drawing
+	// chars.
+	ASCII bool
+}
+
+// renderChars are the chars used to draw a map.
+type renderChars struct {
+	horizontal, vertical, cross rune
+	destroyed                   string
+}
+
+var (
+	unicodeChars = renderChars{'─', '│', '┼', "✗"}
+	asciiChars   = renderChars{'-', '|', '+', "x"}
+)
+
+// RenderMap draws the map to w on a 2D grid where cities are placed by their
+// compass relations and roads are drawn between the cities that are next to
+// each other on the same row or column.
+func RenderMap(w io.Writer, mp Map, opts RenderOptions) error {
+	chars := unicodeChars
+	if opts.ASCII {
+		chars = asciiChars
+	}
+	layout := opts.Layout
+	if layout == nil {
+		layout = mp.Layout()
+	}
+
+	// create city labels.
+	alienCounts := make(map[string]int)
+	trappedCounts := make(map[string]int)
+	for _, alien := range opts.Aliens {
+		alienCounts[alien.CityName]++
+		if alien.IsTrapped {
+			trappedCounts[alien.CityName]++
+		}
+	}
+	labels := make(map[string][]rune, len(layout))
+	var cellWidth, maxX, maxY int
+	for cityName, p := range layout {
+		label := cityName
+		switch {
+		case mp[cityName] == nil:
+			label = chars.destroyed + cityName
+		case trappedCounts[cityName] > 0:
+			label = fmt.Sprintf("%s[%d!]", cityName, alienCounts[cityName])
+		case alienCounts[cityName] > 0:
+			label = fmt.Sprintf("%s[%d]", cityName, alienCounts[cityName])
+		}
+		labels[cityName] = []rune(label)
+		if len(labels[cityName]) > cellWidth {
+			cellWidth = len(labels[cityName])
+		}
+		if p.X > maxX {
+			maxX = p.X
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+	}
+	if len(layout) == 0 {
+		return nil
+	}
+
+	// each city takes a cell and roads are drawn in the gaps between cells.
+	// a cell has four extra columns for the road on the east and it is followed
+	// by a row for the road on the south.
+	cellWidth += 4
+	canvas := make([][]rune, maxY*2+1)
+	for i := range canvas {
+		canvas[i] = []rune(strings.Repeat(" ", (maxX+1)*cellWidth))
+	}
+	for cityName, p := range layout {
+		copy(canvas[p.Y*2][p.X*cellWidth:], labels[cityName])
+	}
+	for _, road := range mp.roads() {
+		// make A the west or north city of the road.
+		a, okA := layout[road.A]
+		b, okB := layout[road.B]
+		if !okA || !okB {
+			continue
+		}
+		if b.X < a.X || b.Y < a.Y {
+			road.A, road.B, a, b = road.B, road.A, b, a
+		}
+		switch {
+		case a.Y == b.Y:
+			row := canvas[a.Y*2]
+			for i := a.X*cellWidth + len(labels[road.A]) + 1; i < b.X*cellWidth-1; i++ {
+				drawRoad(row, i, chars.horizontal, chars)
+			}
+		case a.X == b.X:
+			for y := a.Y*2 + 1; y < b.Y*2; y++ {
+				drawRoad(canvas[y], a.X*cellWidth, chars.vertical, chars)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, row := range canvas {
+		bw.WriteString(strings.TrimRight(string(row), " "))
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// drawRoad draws a road char on the row at i. crossing roads are drawn with the
+// cross char and cities are never overdrawn.
+func drawRoad(row []rune, i int, c rune, chars renderChars) {
+	switch row[i] {
+	case ' ':
+		row[i] = c
+	case chars.horizontal, chars.vertical:
+		if row[i] != c {
+			row[i] = chars.cross
+		}
+	}
+}
+
+// roads returns the sorted roads between existing cities in the map.
+func (mp Map) roads() []Road {
+	var roads []Road
+	for name, neighbors := range mp.adjacency() {
+		for _, neighborName := range neighbors {
+			if name < neighborName {
+				roads = append(roads, Road{name, neighborName})
+			}
+		}
+	}
+	sortRoads(roads)
+	return roads
+}
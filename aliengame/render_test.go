@@ -0,0 +1,47 @@
+package aliengame
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMap(t *testing.T) {
+	mp, err := ParseMap(strings.NewReader("Foo east=Bar south=Baz\nBaz east=Qux\nBar south=Qux"))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderMap(&buf, mp, RenderOptions{}))
+	require.Equal(t, `Foo ── Bar
+│      │
+Baz ── Qux
+`, buf.String())
+
+	// destroy a city and render with the initial layout.
+	layout := mp.Layout()
+	state := mp.Clone()
+	delete(state, "Bar")
+	buf.Reset()
+	require.NoError(t, RenderMap(&buf, state, RenderOptions{
+		Layout: layout,
+		Aliens: []Alien{
+			{Name: "A1", CityName: "Foo"},
+			{Name: "A2", CityName: "Foo"},
+			{Name: "A3", CityName: "Qux", IsTrapped: true},
+		},
+		ASCII: true,
+	}))
+	require.Equal(t, `Foo[2]     xBar
+|
+Baz ------ Qux[1!]
+`, buf.String())
+}
+
+func TestRenderMapEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderMap(&buf, Map{}, RenderOptions{}))
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,121 @@
+package aliengame
+
+import (
+	"context"
+	"time"
+)
+
+// EndReason explains why a game has ended.
+type EndReason string
+
+const (
+	// AllDead is the reason when all aliens are dead (or none were spawned).
+	AllDead EndReason = "all-dead"
+	// AllTrapped is the reason when all living aliens are trapped in cities
+	// with no neighbors left.
+	AllTrapped EndReason = "all-trapped"
+	// MoveCapReached is the reason when none of the living aliens can move
+	// anymore and at least one of them has reached to the max move threshold.
+	MoveCapReached EndReason = "move-cap-reached"
+	// IterationLimitReached is the reason when the game is stopped because
+	// RunOptions.MaxIterations is reached.
+	IterationLimitReached EndReason = "iteration-limit-reached"
+	// Cancelled is the reason when the game is stopped because the context is
+	// cancelled or RunOptions.Timeout is exceeded.
+	Cancelled EndReason = "cancelled"
+	// InvariantViolated is the reason when the game is ended because the
+	// state of the world is not consistent, see WithInvariantCheck.
+	InvariantViolated EndReason = "invariant-violated"
+)
+
+// RunOptions configures World.Run. zero value of each option means no limit.
+type RunOptions struct {
+	// MaxIterations is the max number of times that world will be resumed.
+	MaxIterations int
+
+	// Timeout is the max wall-clock duration for the whole game.
+	Timeout time.Duration
+
+	// StepDelay is the duration to wait between each iteration.
+	StepDelay time.Duration
+
+	// OnIteration is called after each iteration with the number of the
+	// iteration, if it is set.
+	OnIteration func(iteration int)
+}
+
+// RunResult is the outcome of World.Run.
+type RunResult struct {
+	// Reason explains why the game has ended.
+	Reason EndReason `json:"reason"`
+
+	// Iterations is the total number of iterations that world has been resumed.
+	Iterations int `json:"iterations"`
+}
+
+// Run continuously resumes the world until it cannot be resumed anymore, ctx is
+// cancelled or one of the limits in opts is reached.
+// the world can still be resumed after Run returns because of cancellation or
+// the iteration limit, use End to end the world if it is not needed anymore.
+//
+// cancelling ctx also stops waiting for the players' moves, see Resume.
+//
+// err is set to ctx's error when the game is cancelled, or to the invariant
+// violation that has ended the game.
+func (w *World) Run(ctx context.Context, opts RunOptions) (result RunResult, err error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		w.ma.Lock()
+		defer w.ma.Unlock()
+		result.Iterations = w.iteration
+	}()
+	for i := 0; ; i++ {
+		if opts.MaxIterations > 0 && i >= opts.MaxIterations {
+			return RunResult{Reason: IterationLimitReached}, nil
+		}
+		if i > 0 && opts.StepDelay > 0 {
+			select {
+			case <-ctx.Done():
+				return RunResult{Reason: Cancelled}, ctx.Err()
+			case <-time.After(opts.StepDelay):
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return RunResult{Reason: Cancelled}, ctx.Err()
+		default:
+		}
+		canResume, err := w.resume(ctx)
+		if err != nil {
+			return RunResult{Reason: Cancelled}, err
+		}
+		if opts.OnIteration != nil {
+			opts.OnIteration(i + 1)
+		}
+		if !canResume {
+			if err := w.Err(); err != nil {
+				return RunResult{Reason: InvariantViolated}, err
+			}
+			return RunResult{Reason: w.endReason()}, nil
+		}
+	}
+}
+
+// endReason determines the reason of why world cannot be resumed anymore.
+func (w *World) endReason() EndReason {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	if len(w.aliens) == 0 {
+		return AllDead
+	}
+	for _, alien := range w.aliens {
+		if !alien.IsTrapped {
+			return MoveCapReached
+		}
+	}
+	return AllTrapped
+}
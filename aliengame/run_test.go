@@ -0,0 +1,122 @@
+package aliengame
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWorld creates a world on the map defination with the options, and
+// spawns alienCount aliens randomly and the aliens at the cities, by alien names.
+func newTestWorld(t *testing.T, mapdef string, alienCount int, aliens map[string]string, options ...Option) *World {
+	mp, err := ParseMap(strings.NewReader(mapdef))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	world := New(mp, nil, options...)
+	world.SpawnAlien(alienCount)
+	for alienName, cityName := range aliens {
+		require.NoError(t, world.SpawnAlienAt(alienName, cityName))
+	}
+	return world
+}
+
+// withEvents makes the world send the game events to the events channel like
+// the one given to New.
+func withEvents(events chan Event) Option {
+	return func(w *World) {
+		w.events = events
+	}
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct {
+		name       string
+		mapdef     string
+		alienCount int
+		opts       RunOptions
+		result     RunResult
+	}{
+		{
+			"no aliens",
+			"Foo north=Bar",
+			0,
+			RunOptions{},
+			RunResult{AllDead, 1},
+		},
+		{
+			"move cap",
+			"Foo north=Bar",
+			1,
+			RunOptions{},
+			RunResult{MoveCapReached, alienMaxMoveCount},
+		},
+		{
+			"iteration limit",
+			"Foo north=Bar",
+			1,
+			RunOptions{MaxIterations: 5},
+			RunResult{IterationLimitReached, 5},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			world := newTestWorld(t, tt.mapdef, tt.alienCount, nil)
+			result, err := world.Run(context.Background(), tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.result, result)
+			// world is only resumable when it is stopped by the limits.
+			require.Equal(t, tt.result.Reason == IterationLimitReached, world.Resume())
+		})
+	}
+}
+
+func TestRunAllTrapped(t *testing.T) {
+	mp := Map{"Foo": &City{Name: "Foo"}}
+	world := New(mp, nil)
+	world.SpawnAlien(1)
+	result, err := world.Run(context.Background(), RunOptions{})
+	require.NoError(t, err)
+	require.Equal(t, RunResult{AllTrapped, 1}, result)
+}
+
+func TestRunCancelled(t *testing.T) {
+	events := make(chan Event)
+	world := New(Map{"Foo": &City{Name: "Foo"}}, events)
+	world.SpawnAlien(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := world.Run(ctx, RunOptions{})
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, RunResult{Cancelled, 0}, result)
+	world.End()
+	_, ok := <-events
+	require.False(t, ok)
+	require.False(t, world.Resume())
+}
+
+func TestRunTimeout(t *testing.T) {
+	world := newTestWorld(t, "Foo north=Bar", 1, nil)
+	result, err := world.Run(context.Background(), RunOptions{
+		Timeout:   50 * time.Millisecond,
+		StepDelay: 20 * time.Millisecond,
+	})
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Equal(t, Cancelled, result.Reason)
+	require.True(t, result.Iterations >= 1)
+}
+
+func TestRunOnIteration(t *testing.T) {
+	world := newTestWorld(t, "Foo north=Bar", 1, nil)
+	var iterations []int
+	_, err := world.Run(context.Background(), RunOptions{
+		MaxIterations: 3,
+		OnIteration: func(iteration int) {
+			iterations = append(iterations, iteration)
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, iterations)
+}
@@ -0,0 +1,217 @@
+package aliengame
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// scenarioPlayer is the player that submits the forced moves of scenarios.
+const scenarioPlayer = "scenario"
+
+// Scenario is a scripted game with its expected outcomes. scenarios are
+// written in JSON:
+//
+//	{
+//	  "name": "aliens meet in the middle",
+//	  "map": ["Foo east=Bar", "Bar east=Baz"],
+//	  "seed": 1,
+//	  "spawns": {"A1": "Foo", "A2": "Baz"},
+//	  "moves": {"1": {"A1": "east"}},
+//	  "foundings": {"2": ["Qux west=Baz"]},
+//	  "expect": {"destroyed": {"Bar": 1}, "survivors": [], "reason": "all-dead"}
+//	}
+type Scenario struct {
+	// Name describes the scenario.
+	Name string `json:"name"`
+
+	// Map is the lines of the map defination.
+	Map []string `json:"map"`
+
+	// Seed is the seed of the world, see WithSeed.
+	Seed int64 `json:"seed"`
+
+	// Spawns are the cities that aliens spawn at, by alien names.
+	Spawns map[string]string `json:"spawns"`
+
+	// Moves are the forced moves of aliens by iterations. each move is the
+	// direction of an alien by its name. aliens without a forced move at an
+	// iteration move randomly.
+	Moves map[int]map[string]string `json:"moves"`
+
+	// Foundings are the cities founded at the end of iterations, see
+	// WithFoundings. each city is a line of the map defination.
+	Foundings map[int][]string `json:"foundings"`
+
+	// MaxIterations is the max number of iterations, zero means no limit.
+	MaxIterations int `json:"maxIterations"`
+
+	// Expect is the expected outcome of the scenario.
+	Expect ScenarioExpectation `json:"expect"`
+}
+
+// ScenarioExpectation is the expected outcome of a scenario. unset
+// expectations are not checked.
+type ScenarioExpectation struct {
+	// Destroyed are the iterations that cities are destroyed at, by city
+	// names. no other city must be destroyed.
+	Destroyed map[string]int `json:"destroyed"`
+
+	// Survivors are the names of the living aliens at the end of the game.
+	Survivors []string `json:"survivors"`
+
+	// Reason is the reason of why the game has ended.
+	Reason EndReason `json:"reason"`
+
+	// Iterations is the number of iterations that the game lasted.
+	Iterations int `json:"iterations"`
+}
+
+// ParseScenario parses a scenario in JSON by reading from r.
+func ParseScenario(r io.Reader) (Scenario, error) {
+	var s Scenario
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&s); err != nil {
+		return Scenario{}, fmt.Errorf("invalid scenario: %w", err)
+	}
+	return s, nil
+}
+
+// Run plays the scenario and checks its expectations. failures explain the
+// unmet expectations. err is not nil when the scenario cannot be played
+// because it is not valid.
+func (s Scenario) Run(ctx context.Context) (failures []string, err error) {
+	mp, err := ParseMap(strings.NewReader(strings.Join(s.Map, "\n")))
+	if err != nil {
+		return nil, err
+	}
+	if err := CraftMap(mp); err != nil {
+		return nil, err
+	}
+	foundings, err := s.foundings()
+	if err != nil {
+		return nil, err
+	}
+	// forced moves are submitted before each iteration, so there is no need
+	// to wait for them.
+	world := New(mp, nil, WithSeed(s.Seed), WithTurnTimeout(0), WithFoundings(foundings...))
+	defer world.End()
+	var alienNames []string
+	for alienName := range s.Spawns {
+		alienNames = append(alienNames, alienName)
+	}
+	sort.Strings(alienNames)
+	for _, alienName := range alienNames {
+		if err := world.SpawnAlienAt(alienName, s.Spawns[alienName]); err != nil {
+			return nil, err
+		}
+	}
+	forced := make(map[string]bool)
+	for _, moves := range s.Moves {
+		for alienName := range moves {
+			forced[alienName] = true
+		}
+	}
+	if len(forced) > 0 {
+		var forcedNames []string
+		for alienName := range forced {
+			forcedNames = append(forcedNames, alienName)
+		}
+		sort.Strings(forcedNames)
+		if err := world.AddPlayer(scenarioPlayer, forcedNames...); err != nil {
+			return nil, err
+		}
+	}
+	var result RunResult
+	for iteration := 1; ; iteration++ {
+		if s.MaxIterations > 0 && iteration > s.MaxIterations {
+			result.Reason = IterationLimitReached
+			break
+		}
+		for alienName, move := range s.Moves[iteration] {
+			direction, ok := compass.ParseDirection(move)
+			if !ok {
+				return nil, fmt.Errorf("iteration %d: %q is not a direction", iteration, move)
+			}
+			if err := world.Move(scenarioPlayer, alienName, direction); err != nil {
+				return nil, fmt.Errorf("iteration %d: %w", iteration, err)
+			}
+		}
+		if result, err = world.Run(ctx, RunOptions{MaxIterations: 1}); err != nil {
+			return nil, err
+		}
+		if result.Reason != IterationLimitReached {
+			break
+		}
+	}
+	return s.Expect.check(world.Stats(), result.Reason), nil
+}
+
+// foundings parses the cities founded by the scenario in the order of their
+// iterations and names.
+func (s Scenario) foundings() ([]Founding, error) {
+	var iterations []int
+	for iteration := range s.Foundings {
+		iterations = append(iterations, iteration)
+	}
+	sort.Ints(iterations)
+	var foundings []Founding
+	for _, iteration := range iterations {
+		if iteration < 1 {
+			return nil, fmt.Errorf("foundings: iteration %d is not positive", iteration)
+		}
+		mp, err := ParseMap(strings.NewReader(strings.Join(s.Foundings[iteration], "\n")))
+		if err != nil {
+			return nil, fmt.Errorf("foundings at iteration %d: %w", iteration, err)
+		}
+		for _, cityName := range mp.cityNames() {
+			foundings = append(foundings, Founding{iteration, mp[cityName]})
+		}
+	}
+	return foundings, nil
+}
+
+// check compares the outcome of a game with the expectations.
+func (e ScenarioExpectation) check(stats Stats, reason EndReason) (failures []string) {
+	if e.Destroyed != nil {
+		destroyed := make(map[string]int)
+		for _, city := range stats.DestroyedCities {
+			destroyed[city.Name] = city.Iteration
+		}
+		if !reflect.DeepEqual(e.Destroyed, destroyed) {
+			failures = append(failures, fmt.Sprintf("destroyed cities: expected %s, got %s",
+				formatDestroyed(e.Destroyed), formatDestroyed(destroyed)))
+		}
+	}
+	if e.Survivors != nil {
+		survivors := append([]string{}, e.Survivors...)
+		sort.Strings(survivors)
+		if !reflect.DeepEqual(survivors, append([]string{}, stats.Survivors...)) {
+			failures = append(failures, fmt.Sprintf("survivors: expected %v, got %v", survivors, stats.Survivors))
+		}
+	}
+	if e.Reason != "" && e.Reason != reason {
+		failures = append(failures, fmt.Sprintf("reason: expected %s, got %s", e.Reason, reason))
+	}
+	if e.Iterations != 0 && e.Iterations != stats.Iterations {
+		failures = append(failures, fmt.Sprintf("iterations: expected %d, got %d", e.Iterations, stats.Iterations))
+	}
+	return failures
+}
+
+// formatDestroyed formats destroyed cities as sorted name@iteration pairs.
+func formatDestroyed(destroyed map[string]int) string {
+	var pairs []string
+	for name, iteration := range destroyed {
+		pairs = append(pairs, fmt.Sprintf("%s@%d", name, iteration))
+	}
+	sort.Strings(pairs)
+	return "[" + strings.Join(pairs, " ") + "]"
+}
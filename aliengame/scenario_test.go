@@ -0,0 +1,84 @@
+package aliengame
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarios(t *testing.T) {
+	paths, err := filepath.Glob("testdata/scenarios/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		path := path
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			f, err := os.Open(path)
+			require.NoError(t, err)
+			defer f.Close()
+			s, err := ParseScenario(f)
+			require.NoError(t, err)
+			failures, err := s.Run(context.Background())
+			require.NoError(t, err)
+			require.Empty(t, failures, s.Name)
+		})
+	}
+}
+
+func TestScenarioFailures(t *testing.T) {
+	s := Scenario{
+		Map:    []string{"Foo east=Bar"},
+		Spawns: map[string]string{"A1": "Foo", "A2": "Foo"},
+		Expect: ScenarioExpectation{
+			Destroyed:  map[string]int{"Foo": 2},
+			Survivors:  []string{"A1"},
+			Reason:     AllTrapped,
+			Iterations: 5,
+		},
+	}
+	failures, err := s.Run(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"destroyed cities: expected [Foo@2], got [Bar@1]",
+		"survivors: expected [A1], got []",
+		"reason: expected all-trapped, got all-dead",
+		"iterations: expected 5, got 1",
+	}, failures)
+}
+
+func TestScenarioErrors(t *testing.T) {
+	cases := []Scenario{
+		{Map: []string{"Foo up=Bar"}},
+		{Map: []string{"Foo east=Bar"}, Spawns: map[string]string{"A1": "Baz"}},
+		{Map: []string{"Foo east=Bar"}, Moves: map[int]map[string]string{1: {"A1": "east"}}},
+		{
+			Map:    []string{"Foo east=Bar"},
+			Spawns: map[string]string{"A1": "Foo"},
+			Moves:  map[int]map[string]string{1: {"A1": "up"}},
+		},
+		{
+			Map:    []string{"Foo east=Bar"},
+			Spawns: map[string]string{"A1": "Foo"},
+			Moves:  map[int]map[string]string{1: {"A1": "west"}},
+		},
+		{Map: []string{"Foo east=Bar"}, Foundings: map[int][]string{1: {"Baz up=Bar"}}},
+		{Map: []string{"Foo east=Bar"}, Foundings: map[int][]string{0: {"Baz west=Bar"}}},
+	}
+	for _, s := range cases {
+		_, err := s.Run(context.Background())
+		require.Error(t, err)
+	}
+}
+
+func TestParseScenario(t *testing.T) {
+	s, err := ParseScenario(strings.NewReader(`{"name": "foo", "moves": {"2": {"A1": "north"}}}`))
+	require.NoError(t, err)
+	require.Equal(t, Scenario{Name: "foo", Moves: map[int]map[string]string{2: {"A1": "north"}}}, s)
+
+	_, err = ParseScenario(strings.NewReader(`{"nam": "foo"}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package aliengame
+
+import "sort"
+
+// Stats is a summary of the game world.
+type Stats struct {
+	// Iterations is the number of times that world has been resumed.
+	Iterations int `json:"iterations"`
+
+	// DestroyedCities are the destroyed cities in the order of destruction.
+	DestroyedCities []DestroyedCity `json:"destroyedCities"`
+
+	// KilledAliens are the names of aliens died in the destroyed cities, or
+	// killed by KillAlien.
+	KilledAliens []string `json:"killedAliens"`
+
+	// TrappedAliens are the names of living aliens trapped in a city.
+	TrappedAliens []string `json:"trappedAliens"`
+
+	// ExhaustedAliens are the names of living aliens reached to the max
+	// move threshold.
+	ExhaustedAliens []string `json:"exhaustedAliens"`
+
+	// Survivors are the names of all living aliens.
+	Survivors []string `json:"survivors"`
+
+	// RemainingCities is the number of cities left in the map.
+	RemainingCities int `json:"remainingCities"`
+
+	// LargestComponent is the sorted city names of the largest group of
+	// remaining cities that are connected to each other by roads.
+	LargestComponent []string `json:"largestComponent"`
+}
+
+// DestroyedCity is a city destroyed by aliens, or by DestroyCity.
+type DestroyedCity struct {
+	// Name of the city.
+	Name string `json:"name"`
+
+	// Iteration that city is destroyed at.
+	Iteration int `json:"iteration"`
+
+	// Aliens are the names of aliens fought in the city.
+	Aliens []string `json:"aliens"`
+
+	// External is true when the city is destroyed by DestroyCity instead of
+	// a fight, between the iteration and the next one.
+	External bool `json:"external,omitempty"`
+}
+
+// Stats gets a summary of the current status of the world. it can be called at
+// any time but it is most useful after the game has ended.
+func (w *World) Stats() Stats {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	stats := Stats{
+		Iterations:      w.iteration,
+		RemainingCities: len(w.mp),
+	}
+	if components := w.mp.Components(); len(components) > 0 {
+		stats.LargestComponent = components[0]
+	}
+	for _, city := range w.destroyedCities {
+		stats.DestroyedCities = append(stats.DestroyedCities, city)
+		stats.KilledAliens = append(stats.KilledAliens, city.Aliens...)
+	}
+	stats.KilledAliens = append(stats.KilledAliens, w.killedAliens...)
+	for _, alien := range w.aliens {
+		stats.Survivors = append(stats.Survivors, alien.Name)
+		switch {
+		case alien.IsTrapped:
+			stats.TrappedAliens = append(stats.TrappedAliens, alien.Name)
+		case alien.MoveCount >= alienMaxMoveCount:
+			stats.ExhaustedAliens = append(stats.ExhaustedAliens, alien.Name)
+		}
+	}
+	sort.Strings(stats.KilledAliens)
+	sort.Strings(stats.TrappedAliens)
+	sort.Strings(stats.ExhaustedAliens)
+	sort.Strings(stats.Survivors)
+	return stats
+}
@@ -0,0 +1,35 @@
+package aliengame
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(2)
+	for world.Resume() {
+	}
+	require.Equal(t, Stats{
+		Iterations: 1,
+		DestroyedCities: []DestroyedCity{
+			{"Foo", 1, []string{"A2", "A1"}, false},
+		},
+		KilledAliens: []string{"A1", "A2"},
+	}, world.Stats())
+}
+
+func TestStatsSurvivors(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(1)
+	for world.Resume() {
+	}
+	require.Equal(t, Stats{
+		Iterations:       1,
+		TrappedAliens:    []string{"A1"},
+		Survivors:        []string{"A1"},
+		RemainingCities:  1,
+		LargestComponent: []string{"Foo"},
+	}, world.Stats())
+}
@@ -0,0 +1,105 @@
+package aliengame
+
+// Subscription is a subscription to the game events of a world. unlike the
+// events channel given to New, subscribers never block the world, so any number
+// of them can watch the same world.
+type Subscription struct {
+	// C receives the game events. it is closed when the world ends, the
+	// subscription is cancelled or the subscriber cannot keep up with the world.
+	C <-chan Event
+
+	c chan Event
+	w *World
+
+	// dropped is true when the subscriber could not keep up with the world.
+	// protected by the world's mutex.
+	dropped bool
+}
+
+// Subscribe subscribes to the game events of the world. buffer is the number of
+// events that can wait to be received, the subscription is dropped when its
+// buffer is full and the world emits a new event.
+// C is closed immediately if the world has already ended.
+func (w *World) Subscribe(buffer int) *Subscription {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	c := make(chan Event, buffer)
+	s := &Subscription{C: c, c: c, w: w}
+	if w.done {
+		close(c)
+		return s
+	}
+	if w.subscriptions == nil {
+		w.subscriptions = make(map[*Subscription]bool)
+	}
+	w.subscriptions[s] = true
+	return s
+}
+
+// Cancel cancels the subscription and closes C. it is safe to call Cancel
+// multiple times.
+func (s *Subscription) Cancel() {
+	s.w.ma.Lock()
+	defer s.w.ma.Unlock()
+	s.w.unsubscribe(s)
+}
+
+// Dropped checks if the subscription is dropped because the subscriber could
+// not keep up with the world.
+func (s *Subscription) Dropped() bool {
+	s.w.ma.Lock()
+	defer s.w.ma.Unlock()
+	return s.dropped
+}
+
+// unsubscribe removes the subscription and closes its channel.
+func (w *World) unsubscribe(s *Subscription) {
+	if w.subscriptions[s] {
+		delete(w.subscriptions, s)
+		close(s.c)
+	}
+}
+
+// collector records the events emitted by the world during Collect.
+type collector struct {
+	events []Event
+}
+
+// Collect calls fn and returns all the events emitted by the world meanwhile.
+// unlike subscriptions, no events are dropped, so fn should only resume the
+// world a limited number of times.
+func (w *World) Collect(fn func()) []Event {
+	c := &collector{}
+	w.ma.Lock()
+	if w.collectors == nil {
+		w.collectors = make(map[*collector]bool)
+	}
+	w.collectors[c] = true
+	w.ma.Unlock()
+	defer func() {
+		w.ma.Lock()
+		defer w.ma.Unlock()
+		delete(w.collectors, c)
+	}()
+	fn()
+	// other goroutines may still emit events till the collector is removed.
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return append([]Event(nil), c.events...)
+}
+
+// publish sends the event to all subscribers without blocking. subscribers
+// with full buffers are dropped.
+func (w *World) publish(e Event) {
+	for c := range w.collectors {
+		c.events = append(c.events, e)
+	}
+	for s := range w.subscriptions {
+		select {
+		case s.c <- e:
+		default:
+			s.dropped = true
+			w.unsubscribe(s)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package aliengame
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(1)
+	s1 := world.Subscribe(1)
+	s2 := world.Subscribe(1)
+	s3 := world.Subscribe(0)
+	s2.Cancel()
+	s2.Cancel()
+	// alien gets trapped, city has no neighbors.
+	world.Resume()
+
+	event, ok := <-s1.C
+	require.True(t, ok)
+	require.IsType(t, AlienTrappedEvent{}, event)
+	// second event is dropped, so the subscription is.
+	_, ok = <-s1.C
+	require.False(t, ok)
+	require.True(t, s1.Dropped())
+
+	_, ok = <-s2.C
+	require.False(t, ok)
+	require.False(t, s2.Dropped())
+
+	_, ok = <-s3.C
+	require.False(t, ok)
+	require.True(t, s3.Dropped())
+}
+
+func TestSubscribeEnded(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	s1 := world.Subscribe(10)
+	world.End()
+	_, ok := <-s1.C
+	require.False(t, ok)
+	require.False(t, s1.Dropped())
+
+	s2 := world.Subscribe(10)
+	_, ok = <-s2.C
+	require.False(t, ok)
+}
+
+func TestCollect(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(1)
+	// the events are not dropped like the subscription with a small buffer.
+	sub := world.Subscribe(1)
+	events := world.Collect(func() {
+		world.Resume()
+	})
+	require.Len(t, events, 2)
+	require.IsType(t, AlienTrappedEvent{}, events[0])
+	require.IsType(t, CityHasNoNeighborsEvent{}, events[1])
+	require.True(t, sub.Dropped())
+
+	require.Empty(t, world.Collect(func() {}))
+
+	// events emitted by other goroutines meanwhile are collected too.
+	world = New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(1)
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				world.TeleportAlien("A1", "Foo")
+			}
+		}
+	}()
+	events = world.Collect(func() { time.Sleep(10 * time.Millisecond) })
+	close(stop)
+	<-done
+	require.NotEmpty(t, events)
+	for _, event := range events {
+		require.IsType(t, AlienTeleportedEvent{}, event)
+	}
+}
@@ -0,0 +1,212 @@
+package aliengame
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/template"
+	"time"
+)
+
+const (
+	// svgCellWidth and svgCellHeight are the distances between cities that are
+	// next to each other on the grid.
+	svgCellWidth  = 120
+	svgCellHeight = 90
+	// svgMargin is the space around the map.
+	svgMargin = 60
+	// svgAlienGap is the distance between alien markers in a city.
+	svgAlienGap = 10
+
+	defaultFrameDuration = 500 * time.Millisecond
+)
+
+// Frame is a snapshot of the world at an iteration.
+type Frame struct {
+	// Iteration is the number of times that world has been resumed.
+	Iteration int
+
+	// Map is the map state at the iteration.
+	Map Map
+
+	// Aliens are the living aliens at the iteration.
+	Aliens []Alien
+}
+
+// Frame gets a snapshot of the current status of the world.
+func (w *World) Frame() Frame {
+	w.ma.Lock()
+	defer w.ma.Unlock()
+	return Frame{
+		Iteration: w.iteration,
+		Map:       w.mp.Clone(),
+		Aliens:    w.alienList(),
+	}
+}
+
+// SVGOptions configures RenderSVG and RenderSVGTimeline.
+type SVGOptions struct {
+	// Layout is the positions of cities on the grid. it defaults to the layout
+	// of the (first) frame's map. cities that are in the layout but not in the
+	// map are drawn as destroyed.
+	Layout map[string]Point
+
+	// FrameDuration is the duration that each frame is shown in a timeline.
+	// it defaults to 500ms.
+	FrameDuration time.Duration
+}
+
+// svgData is the template data of an svg image.
+type svgData struct {
+	Width, Height int
+	Frames        []svgFrame
+	// Animated is true when the frames are shown one after another.
+	Animated bool
+}
+
+// svgFrame is the template data of a frame.
+type svgFrame struct {
+	Iteration int
+	// Begin and Duration are the timing of the frame in seconds.
+	Begin, Duration float64
+	// Last is true for the last frame which stays visible once shown.
+	Last   bool
+	Roads  []svgLine
+	Cities []svgCity
+	Aliens []svgAlien
+}
+
+type svgLine struct {
+	X1, Y1, X2, Y2 int
+}
+
+type svgCity struct {
+	Name      string
+	X, Y      int
+	Destroyed bool
+}
+
+type svgAlien struct {
+	Name    string
+	X, Y    int
+	Trapped bool
+}
+
+var svgTemplate = template.Must(template.New("svg").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}" font-family="sans-serif" font-size="12">
+<rect width="100%" height="100%" fill="#ffffff"/>
+{{- $animated := .Animated}}
+{{- range .Frames}}
+<g class="frame"{{if $animated}} visibility="hidden"{{end}}>
+{{- if $animated}}
+<set attributeName="visibility" to="visible" begin="{{printf "%.3f" .Begin}}s"{{if .Last}} fill="freeze"{{else}} dur="{{printf "%.3f" .Duration}}s"{{end}}/>
+{{- end}}
+<text x="10" y="20" fill="#333333">iteration {{.Iteration}}</text>
+{{- range .Roads}}
+<line x1="{{.X1}}" y1="{{.Y1}}" x2="{{.X2}}" y2="{{.Y2}}" stroke="#888888" stroke-width="2"/>
+{{- end}}
+{{- range .Cities}}
+<g class="city{{if .Destroyed}} destroyed{{end}}">
+<circle cx="{{.X}}" cy="{{.Y}}" r="16" fill="{{if .Destroyed}}#dddddd{{else}}#6fa8dc{{end}}" stroke="{{if .Destroyed}}#aaaaaa{{else}}#3d85c6{{end}}"{{if .Destroyed}} stroke-dasharray="4 2"{{end}}/>
+<text x="{{.X}}" y="{{.Y}}" dy="-22" text-anchor="middle" fill="{{if .Destroyed}}#aaaaaa{{else}}#000000{{end}}">{{html .Name}}</text>
+</g>
+{{- end}}
+{{- range .Aliens}}
+<circle class="alien{{if .Trapped}} trapped{{end}}" cx="{{.X}}" cy="{{.Y}}" r="4" fill="{{if .Trapped}}#e69138{{else}}#cc0000{{end}}"><title>{{html .Name}}</title></circle>
+{{- end}}
+</g>
+{{- end}}
+</svg>
+`))
+
+// RenderSVG draws the frame as an svg image to w. cities are placed by their
+// compass relations, roads are drawn as lines, and aliens are drawn as markers
+// in their cities.
+func RenderSVG(w io.Writer, frame Frame, opts SVGOptions) error {
+	return renderSVG(w, []Frame{frame}, opts, false)
+}
+
+// RenderSVGTimeline draws the frames as an animated svg image to w. each frame
+// is shown for the frame duration one after another and the last frame stays.
+// the layout of the first frame is used for all frames by default so cities
+// keep their positions and destroyed ones are shown.
+func RenderSVGTimeline(w io.Writer, frames []Frame, opts SVGOptions) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("there must be at least one frame in the timeline")
+	}
+	return renderSVG(w, frames, opts, true)
+}
+
+func renderSVG(w io.Writer, frames []Frame, opts SVGOptions, animated bool) error {
+	layout := opts.Layout
+	if layout == nil {
+		layout = frames[0].Map.Layout()
+	}
+	duration := opts.FrameDuration
+	if duration <= 0 {
+		duration = defaultFrameDuration
+	}
+	var maxX, maxY int
+	for _, p := range layout {
+		if p.X > maxX {
+			maxX = p.X
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+	}
+	data := svgData{
+		Width:    maxX*svgCellWidth + svgMargin*2,
+		Height:   maxY*svgCellHeight + svgMargin*2,
+		Animated: animated,
+	}
+	for i, frame := range frames {
+		data.Frames = append(data.Frames, newSVGFrame(frame, layout))
+		data.Frames[i].Begin = float64(i) * duration.Seconds()
+		data.Frames[i].Duration = duration.Seconds()
+		data.Frames[i].Last = i == len(frames)-1
+	}
+	return svgTemplate.Execute(w, data)
+}
+
+// newSVGFrame places the cities, roads and aliens of the frame on the layout.
+func newSVGFrame(frame Frame, layout map[string]Point) svgFrame {
+	position := func(p Point) (x, y int) {
+		return p.X*svgCellWidth + svgMargin, p.Y*svgCellHeight + svgMargin
+	}
+	f := svgFrame{Iteration: frame.Iteration}
+	var cityNames []string
+	for cityName := range layout {
+		cityNames = append(cityNames, cityName)
+	}
+	sort.Strings(cityNames)
+	for _, cityName := range cityNames {
+		x, y := position(layout[cityName])
+		_, ok := frame.Map[cityName]
+		f.Cities = append(f.Cities, svgCity{cityName, x, y, !ok})
+	}
+	for _, road := range frame.Map.roads() {
+		a, okA := layout[road.A]
+		b, okB := layout[road.B]
+		if !okA || !okB {
+			continue
+		}
+		x1, y1 := position(a)
+		x2, y2 := position(b)
+		f.Roads = append(f.Roads, svgLine{x1, y1, x2, y2})
+	}
+	// line up the aliens in the middle of their cities.
+	aliensByCity := make(map[string][]Alien)
+	for _, alien := range frame.Aliens {
+		aliensByCity[alien.CityName] = append(aliensByCity[alien.CityName], alien)
+	}
+	for _, cityName := range cityNames {
+		aliens := aliensByCity[cityName]
+		sort.Slice(aliens, func(i, j int) bool { return aliens[i].Name < aliens[j].Name })
+		x, y := position(layout[cityName])
+		x -= (len(aliens) - 1) * svgAlienGap / 2
+		for i, alien := range aliens {
+			f.Aliens = append(f.Aliens, svgAlien{alien.Name, x + i*svgAlienGap, y, alien.IsTrapped})
+		}
+	}
+	return f
+}
@@ -0,0 +1,66 @@
+package aliengame
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countSVGElements validates the svg and counts its elements by their names.
+func countSVGElements(t *testing.T, svg string) map[string]int {
+	counts := make(map[string]int)
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return counts
+		}
+		require.NoError(t, err)
+		if e, ok := token.(xml.StartElement); ok {
+			counts[e.Name.Local]++
+		}
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	mp, err := ParseMap(strings.NewReader("Foo east=Bar south=Baz"))
+	require.NoError(t, err)
+	require.NoError(t, CraftMap(mp))
+	world := New(mp, nil)
+	world.SpawnAlien(1)
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderSVG(&buf, world.Frame(), SVGOptions{}))
+	counts := countSVGElements(t, buf.String())
+	require.Equal(t, 1, counts["svg"])
+	require.Equal(t, 2, counts["line"])
+	require.Equal(t, 3+1, counts["circle"])
+	require.Equal(t, 0, counts["set"])
+}
+
+func TestRenderSVGTimeline(t *testing.T) {
+	world := New(Map{"Foo": &City{Name: "Foo"}}, nil)
+	world.SpawnAlien(2)
+	frames := []Frame{world.Frame()}
+	for world.Resume() {
+	}
+	frames = append(frames, world.Frame())
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderSVGTimeline(&buf, frames, SVGOptions{FrameDuration: time.Second}))
+	svg := buf.String()
+	counts := countSVGElements(t, svg)
+	require.Equal(t, 2, counts["set"])
+	// the city and two aliens in the first frame, and the destroyed city in
+	// the last frame.
+	require.Equal(t, 2+2, counts["circle"])
+	require.True(t, strings.Contains(svg, `begin="1.000s" fill="freeze"`))
+	require.True(t, strings.Contains(svg, `class="city destroyed"`))
+
+	require.Error(t, RenderSVGTimeline(&buf, nil, SVGOptions{}))
+}
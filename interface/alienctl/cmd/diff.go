@@ -0,0 +1,143 @@
+package aliengamecmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around the changes.
+	diffContext = 3
+
+	// maxDiffCells is the max size of the table used to find the common lines,
+	// bigger changes are shown as all lines removed and added.
+	maxDiffCells = 10000000
+)
+
+// diffOp is a line in a diff.
+type diffOp struct {
+	// kind is ' ' for unchanged, '-' for removed and '+' for added lines.
+	kind byte
+	line string
+}
+
+// printUnifiedDiff prints the changes from a to b to w in the unified diff
+// format. nothing is printed when they are the same.
+func printUnifiedDiff(w io.Writer, aName, bName, a, b string) {
+	if a == b {
+		return
+	}
+	ops := diffLines(splitLines(a), splitLines(b))
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", aName, bName)
+	// aLine and bLine are the line numbers of the next op in a and b.
+	aLine, bLine := 1, 1
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			aLine++
+			bLine++
+			i++
+			continue
+		}
+		// a hunk starts with the context before the change and ends when
+		// there are more unchanged lines than twice the context.
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for unchanged := 0; end < len(ops) && unchanged <= 2*diffContext; end++ {
+			if ops[end].kind == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+		}
+		for end > i && ops[end-1].kind == ' ' {
+			end--
+		}
+		if end += diffContext; end > len(ops) {
+			end = len(ops)
+		}
+		hunkA, hunkB := aLine-(i-start), bLine-(i-start)
+		var aCount, bCount int
+		var hunk strings.Builder
+		for _, op := range ops[start:end] {
+			if op.kind != '+' {
+				aCount++
+			}
+			if op.kind != '-' {
+				bCount++
+			}
+			fmt.Fprintf(&hunk, "%c%s\n", op.kind, op.line)
+		}
+		fmt.Fprintf(w, "@@ -%d,%d +%d,%d @@\n%s", hunkA, aCount, hunkB, bCount, hunk.String())
+		aLine, bLine = hunkA+aCount, hunkB+bCount
+		i = end
+	}
+}
+
+// splitLines splits s into lines without the line endings.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines finds the changes from lines a to b by using the longest common
+// lines between them.
+func diffLines(a, b []string) []diffOp {
+	var prefix, suffix []diffOp
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		prefix = append(prefix, diffOp{' ', a[0]})
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		suffix = append([]diffOp{{' ', a[len(a)-1]}}, suffix...)
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	ops := prefix
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range b {
+			ops = append(ops, diffOp{'+', line})
+		}
+		return append(ops, suffix...)
+	}
+	// lcs[i][j] is the length of the longest common lines of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	return append(ops, suffix...)
+}
@@ -0,0 +1,52 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintUnifiedDiff(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	a := strings.Join(lines, "\n") + "\n"
+	lines[1] = "changed 2"
+	lines = append(lines[:10], lines[11:]...)
+	lines = append(lines, "line 21")
+	b := strings.Join(lines, "\n") + "\n"
+
+	var buf bytes.Buffer
+	printUnifiedDiff(&buf, "a", "b", a, b)
+	require.Equal(t, `--- a
++++ b
+@@ -1,5 +1,5 @@
+ line 1
+-line 2
++changed 2
+ line 3
+ line 4
+ line 5
+@@ -8,7 +8,6 @@
+ line 8
+ line 9
+ line 10
+-line 11
+ line 12
+ line 13
+ line 14
+@@ -18,3 +17,4 @@
+ line 18
+ line 19
+ line 20
++line 21
+`, buf.String())
+
+	buf.Reset()
+	printUnifiedDiff(&buf, "a", "b", a, a)
+	require.Empty(t, buf.String())
+}
@@ -1,43 +1,104 @@
 package aliengamecmd
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/aliengame/invariants"
 	"github.com/spf13/cobra"
 )
 
+const (
+	outputText = "text"
+	outputJSON = "json"
+
+	// maxTimelineFrames is the max number of frames in the svg timeline.
+	maxTimelineFrames = 500
+)
+
 var (
 	mapFilePath string
 	alienCount  int
+	output      string
+	game        gameConfig
 )
 
+// gameConfig configures a game played by alienctl.
+type gameConfig struct {
+	// timeout and maxIterations stops the game early, zero means no limit.
+	timeout       time.Duration
+	maxIterations int
+
+	// render draws the map state on a grid instead of the map defination format.
+	render bool
+
+	// svgPath and svgTimelinePath are the file paths to save the map state and
+	// an animated timeline of the game as svg images, if they are set.
+	svgPath         string
+	svgTimelinePath string
+
+	// debug checks the invariants of the world after every iteration and
+	// fails the game on violations.
+	debug bool
+
+	// rebuildAfter is the number of iterations to rebuild the destroyed
+	// cities after, zero means they are not rebuilt.
+	rebuildAfter int
+}
+
 // New returns a new alienctl command that can be attached to a cli app.
 func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "alienctl",
 		Short: "fight aliens, destroy cities!",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return handler(mapFilePath, alienCount, cmd.OutOrStdout())
+			return handler(cmd.Context(), mapFilePath, alienCount, game, output, cmd.OutOrStdout())
 		},
 	}
 	cmd.Flags().StringVarP(&mapFilePath, "map-file", "m", "", "path to the map file (required)")
 	cmd.Flags().IntVarP(&alienCount, "alien-count", "a", 0, "number of aliens to spawn (required)")
+	cmd.Flags().DurationVarP(&game.timeout, "timeout", "t", 0, "max duration of the game, zero means no timeout")
+	cmd.Flags().IntVarP(&game.maxIterations, "max-iterations", "i", 0, "max number of iterations, zero means no limit")
+	cmd.Flags().StringVarP(&output, "output", "o", outputText, "output format of the game result (text|json)")
+	cmd.Flags().BoolVarP(&game.render, "render", "r", false, "draw the map state on a grid")
+	cmd.Flags().StringVar(&game.svgPath, "svg", "", "path to save the map state as an svg image")
+	cmd.Flags().StringVar(&game.svgTimelinePath, "svg-timeline", "", fmt.Sprintf("path to save the game as an animated svg image, at most %d iterations are sampled evenly", maxTimelineFrames))
+	cmd.Flags().IntVar(&game.rebuildAfter, "rebuild-after", 0, "rebuild the destroyed cities after this many iterations, zero means never")
+	cmd.Flags().BoolVar(&game.debug, "debug", false, "check the consistency of the game engine after every iteration")
 	cmd.MarkFlagRequired("map-file")
 	cmd.MarkFlagRequired("alien-count")
+	cmd.AddCommand(newPlayCmd(), newSimulateCmd(), newMapCmd(), newServeCmd(), newScenarioCmd())
 	return cmd
 }
 
-// handler runs the game by using given inputs.
-func handler(mapFilePath string, alienCount int, w io.Writer) error {
-	mapFile, err := os.Open(mapFilePath)
+// gameResult is the json output of a game.
+type gameResult struct {
+	Reason aliengame.EndReason `json:"reason"`
+	aliengame.Stats
+}
+
+// handler runs the game by using given inputs. game is stopped when ctx is
+// cancelled or one of the limits in config is reached.
+// game events, summary and the map state are printed to w in text output. only
+// the game result is printed in json output.
+func handler(ctx context.Context, mapFilePath string, alienCount int, config gameConfig,
+	output string, w io.Writer) error {
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("unknown output format %q", output)
+	}
+	mp, err := parseMapFile(mapFilePath)
 	if err != nil {
 		return err
 	}
-	defer mapFile.Close()
 
 	// start the game and print game events.
 	events := make(chan aliengame.Event)
@@ -46,23 +107,177 @@ func handler(mapFilePath string, alienCount int, w io.Writer) error {
 	go func() {
 		defer wg.Done()
 		for event := range events {
-			fmt.Fprintf(w, "e>%s\n", event)
+			if output == outputText {
+				fmt.Fprintf(w, "e>%s\n", event)
+			}
 		}
 	}()
-	mp, err := aliengame.ParseMap(mapFile)
-	if err != nil {
-		return err
+	var options []aliengame.Option
+	if config.debug {
+		options = append(options, invariants.WithChecks(false))
 	}
-	if err := aliengame.CraftMap(mp); err != nil {
-		return err
+	if config.rebuildAfter > 0 {
+		options = append(options, aliengame.WithRebuild(config.rebuildAfter))
 	}
-	world := aliengame.New(mp, events)
+	world := aliengame.New(mp, events, options...)
 	world.SpawnAlien(alienCount)
-	for world.Resume() {
+	opts := aliengame.RunOptions{
+		Timeout:       config.timeout,
+		MaxIterations: config.maxIterations,
 	}
+	var tl timeline
+	if config.svgTimelinePath != "" {
+		tl.add(0, world.Frame)
+		opts.OnIteration = func(iteration int) {
+			tl.add(iteration, world.Frame)
+		}
+	}
+	// cancellations and timeouts are reported as the end reason of the game,
+	// so the error can be ignored to still print the map state. invariant
+	// violations are bugs of the game engine and fail the game instead.
+	result, err := world.Run(ctx, opts)
+	world.End()
 	wg.Wait()
+	if result.Reason == aliengame.InvariantViolated {
+		return err
+	}
+
+	// save svg images on the layout of the initial map to show the destroyed cities.
+	layout := mp.Layout()
+	if config.svgPath != "" {
+		err := saveSVG(config.svgPath, func(w io.Writer) error {
+			return aliengame.RenderSVG(w, world.Frame(), aliengame.SVGOptions{Layout: layout})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if config.svgTimelinePath != "" {
+		// the last iteration may have been skipped by sampling.
+		frames := tl.frames
+		if tl.last != result.Iterations {
+			frames = append(frames, world.Frame())
+		}
+		err := saveSVG(config.svgTimelinePath, func(w io.Writer) error {
+			return aliengame.RenderSVGTimeline(w, frames, aliengame.SVGOptions{Layout: layout})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	stats := world.Stats()
+	if output == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(gameResult{result.Reason, stats})
+	}
+	fmt.Fprintf(w, "\nGAME OVER: %s after %d iterations\n", result.Reason, result.Iterations)
+
+	// print summary.
+	fmt.Fprint(w, "\nSUMMARY:\n")
+	if err := printSummary(w, stats); err != nil {
+		return err
+	}
 
 	// print map state.
 	fmt.Fprint(w, "\nMAP STATE:\n")
+	if config.render {
+		// draw on the layout of the initial map to show the destroyed cities.
+		return aliengame.RenderMap(w, world.Map(), aliengame.RenderOptions{
+			Layout: layout,
+			Aliens: world.Aliens(),
+		})
+	}
 	return aliengame.PrintMap(w, world.Map())
 }
+
+// parseMapFile parses and crafts a game map from the map file and the files
+// included by it. included files must be in the directory of the map file.
+func parseMapFile(path string) (aliengame.Map, error) {
+	// report a missing map file with its full path.
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	mp, err := aliengame.ParseMapFS(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	if err := aliengame.CraftMap(mp); err != nil {
+		return nil, err
+	}
+	return mp, nil
+}
+
+// timeline samples the frames of a game evenly to keep at most
+// maxTimelineFrames of them.
+type timeline struct {
+	frames []aliengame.Frame
+
+	// every is the number of iterations between the sampled frames.
+	every int
+
+	// last is the iteration of the last sampled frame.
+	last int
+}
+
+// add samples the frame of the iteration if it is due.
+func (t *timeline) add(iteration int, frame func() aliengame.Frame) {
+	if t.every == 0 {
+		t.every = 1
+	}
+	if iteration%t.every != 0 {
+		return
+	}
+	t.frames = append(t.frames, frame())
+	t.last = iteration
+	// drop every other frame and sample half as often when it is full.
+	if len(t.frames) > maxTimelineFrames {
+		var kept []aliengame.Frame
+		for i := 0; i < len(t.frames); i += 2 {
+			kept = append(kept, t.frames[i])
+		}
+		t.frames = kept
+		t.every *= 2
+		t.last = (len(kept) - 1) * t.every
+	}
+}
+
+// saveSVG creates the file at path and writes the svg image to it with render.
+func saveSVG(path string, render func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := render(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// printSummary prints game stats as a table.
+func printSummary(w io.Writer, stats aliengame.Stats) error {
+	var destroyed []string
+	for _, city := range stats.DestroyedCities {
+		destroyed = append(destroyed, fmt.Sprintf("%s@%d", city.Name, city.Iteration))
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := []struct {
+		name  string
+		count int
+		names []string
+	}{
+		{"cities destroyed", len(stats.DestroyedCities), destroyed},
+		{"cities remaining", stats.RemainingCities, nil},
+		{"largest component", len(stats.LargestComponent), stats.LargestComponent},
+		{"aliens killed", len(stats.KilledAliens), stats.KilledAliens},
+		{"aliens trapped", len(stats.TrappedAliens), stats.TrappedAliens},
+		{"aliens exhausted", len(stats.ExhaustedAliens), stats.ExhaustedAliens},
+		{"survivors", len(stats.Survivors), stats.Survivors},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", row.name, row.count, strings.Join(row.names, " "))
+	}
+	return tw.Flush()
+}
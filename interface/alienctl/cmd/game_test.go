@@ -2,9 +2,15 @@ package aliengamecmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/ilgooz/aliengame/aliengame"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,8 +29,105 @@ func TestAlienCmd(t *testing.T) {
 		"Baz",
 		"Qu-ux",
 		"Yee",
+		"SUMMARY",
 		"MAP STATE",
 	} {
 		require.True(t, strings.Contains(output, word), word)
 	}
 }
+
+func TestAlienCmdCancelled(t *testing.T) {
+	cmd := New()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"-m", testmapPath, "-a", "1"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	require.True(t, strings.Contains(buf.String(), "GAME OVER: cancelled after 0 iterations"))
+}
+
+func TestAlienCmdDebug(t *testing.T) {
+	cmd := New()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"-m", testmapPath, "-a", "3", "--debug"})
+	require.NoError(t, cmd.Execute())
+	require.True(t, strings.Contains(buf.String(), "GAME OVER"))
+}
+
+func TestAlienCmdRebuild(t *testing.T) {
+	cmd := New()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"-m", testmapPath, "-a", "4", "-i", "100", "--rebuild-after", "1", "--debug"})
+	require.NoError(t, cmd.Execute())
+	require.True(t, strings.Contains(buf.String(), "GAME OVER"))
+}
+
+func TestAlienCmdJSONOutput(t *testing.T) {
+	cmd := New()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"-m", testmapPath, "-a", "3", "-o", "json"})
+	require.NoError(t, cmd.Execute())
+	var result gameResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.NotEmpty(t, result.Reason)
+	require.True(t, result.Iterations >= 1)
+}
+
+func TestAlienCmdRender(t *testing.T) {
+	cmd := New()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"-m", testmapPath, "-a", "0", "--render"})
+	require.NoError(t, cmd.Execute())
+	require.True(t, strings.HasSuffix(buf.String(), `MAP STATE:
+         Bee
+         │
+         Bar ──── Yee
+         │
+Baz ──── Foo
+         │
+         Qu-ux
+`), buf.String())
+}
+
+func TestAlienCmdSVG(t *testing.T) {
+	dir, err := ioutil.TempDir("", "alienctl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	svgPath := filepath.Join(dir, "state.svg")
+	timelinePath := filepath.Join(dir, "timeline.svg")
+
+	cmd := New()
+	cmd.SetOut(ioutil.Discard)
+	cmd.SetArgs([]string{"-m", testmapPath, "-a", "3", "-i", "5", "--svg", svgPath,
+		"--svg-timeline", timelinePath})
+	require.NoError(t, cmd.Execute())
+	for _, path := range []string{svgPath, timelinePath} {
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(data), "<svg"))
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	var tl timeline
+	var taken int
+	for i := 0; i <= 2000; i++ {
+		iteration := i
+		tl.add(iteration, func() aliengame.Frame {
+			taken++
+			return aliengame.Frame{Iteration: iteration}
+		})
+	}
+	require.True(t, len(tl.frames) <= maxTimelineFrames)
+	// frames of the skipped iterations are not even taken.
+	require.Equal(t, 1001, taken)
+	for i, frame := range tl.frames {
+		require.Equal(t, i*tl.every, frame.Iteration)
+	}
+	require.Equal(t, tl.frames[len(tl.frames)-1].Iteration, tl.last)
+}
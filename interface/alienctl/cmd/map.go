@@ -0,0 +1,119 @@
+package aliengamecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pathFrom string
+	pathTo   string
+)
+
+// newMapCmd returns a command to work with map files.
+func newMapCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "map",
+		Short: "work with map files",
+	}
+	cmd.AddCommand(newMapAnalyzeCmd(), newMapEditCmd(), newMapDiffCmd(), newMapMergeCmd(), newMapFmtCmd())
+	return cmd
+}
+
+// newMapAnalyzeCmd returns a command to analyze the city graph of a map.
+func newMapAnalyzeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "analyze the cities and roads of a map",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mapAnalyzeHandler(mapFilePath, pathFrom, pathTo, output, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&mapFilePath, "map-file", "m", "", "path to the map file (required)")
+	cmd.Flags().StringVar(&pathFrom, "from", "", "city to find the shortest path from")
+	cmd.Flags().StringVar(&pathTo, "to", "", "city to find the shortest path to")
+	cmd.Flags().StringVarP(&output, "output", "o", outputText, "output format of the analysis (text|json)")
+	cmd.MarkFlagRequired("map-file")
+	return cmd
+}
+
+// mapAnalysis is the analysis result of a map.
+type mapAnalysis struct {
+	Cities             int              `json:"cities"`
+	Components         [][]string       `json:"components"`
+	DegreeDistribution map[int]int      `json:"degreeDistribution"`
+	ArticulationPoints []string         `json:"articulationPoints"`
+	Bridges            []aliengame.Road `json:"bridges"`
+	Diameter           int              `json:"diameter"`
+	ShortestPath       []string         `json:"shortestPath,omitempty"`
+}
+
+// mapAnalyzeHandler analyzes the map and prints the analysis to w in the given
+// output format. shortest path is only calculated when both from and to are set.
+func mapAnalyzeHandler(mapFilePath, from, to, output string, w io.Writer) error {
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("unknown output format %q", output)
+	}
+	if (from == "") != (to == "") {
+		return fmt.Errorf("both from and to cities are needed to find the shortest path")
+	}
+	mp, err := parseMapFile(mapFilePath)
+	if err != nil {
+		return err
+	}
+	analysis := mapAnalysis{
+		Cities:             len(mp),
+		Components:         mp.Components(),
+		DegreeDistribution: mp.DegreeDistribution(),
+		ArticulationPoints: mp.ArticulationPoints(),
+		Bridges:            mp.Bridges(),
+		Diameter:           mp.Diameter(),
+	}
+	if from != "" {
+		path, ok := mp.ShortestPath(from, to)
+		if !ok {
+			return fmt.Errorf("there is no path between %q and %q", from, to)
+		}
+		analysis.ShortestPath = path
+	}
+
+	if output == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(analysis)
+	}
+	var degrees []int
+	for degree := range analysis.DegreeDistribution {
+		degrees = append(degrees, degree)
+	}
+	sort.Ints(degrees)
+	var degreeDist []string
+	for _, degree := range degrees {
+		degreeDist = append(degreeDist, fmt.Sprintf("%d:%d", degree, analysis.DegreeDistribution[degree]))
+	}
+	var bridges []string
+	for _, road := range analysis.Bridges {
+		bridges = append(bridges, fmt.Sprintf("%s-%s", road.A, road.B))
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "cities\t%d\n", analysis.Cities)
+	fmt.Fprintf(tw, "components\t%d\n", len(analysis.Components))
+	for i, component := range analysis.Components {
+		fmt.Fprintf(tw, "  #%d\t%d\t%s\n", i+1, len(component), strings.Join(component, " "))
+	}
+	fmt.Fprintf(tw, "degree distribution\t%s\n", strings.Join(degreeDist, " "))
+	fmt.Fprintf(tw, "articulation points\t%s\n", strings.Join(analysis.ArticulationPoints, " "))
+	fmt.Fprintf(tw, "bridges\t%s\n", strings.Join(bridges, " "))
+	fmt.Fprintf(tw, "diameter\t%d\n", analysis.Diameter)
+	if analysis.ShortestPath != nil {
+		fmt.Fprintf(tw, "shortest path\t%s\n", strings.Join(analysis.ShortestPath, " -> "))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,62 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAnalyzeCmd(t *testing.T) {
+	analyze := func(args ...string) (string, error) {
+		cmd := New()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs(append([]string{"map", "analyze", "-m", testmapPath}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := analyze("-o", "json", "--from", "Baz", "--to", "Yee")
+	require.NoError(t, err)
+	var analysis mapAnalysis
+	require.NoError(t, json.Unmarshal([]byte(out), &analysis))
+	require.Equal(t, 6, analysis.Cities)
+	require.Len(t, analysis.Components, 1)
+	require.Equal(t, []string{"Bar", "Foo"}, analysis.ArticulationPoints)
+	require.Equal(t, 3, analysis.Diameter)
+	require.Equal(t, []string{"Baz", "Foo", "Bar", "Yee"}, analysis.ShortestPath)
+
+	out, err = analyze()
+	require.NoError(t, err)
+	require.True(t, strings.Contains(out, "articulation points  Bar Foo"), out)
+
+	_, err = analyze("--from", "Baz")
+	require.Error(t, err)
+}
+
+func TestMapAnalyzeCmdInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "include")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "continents"), 0755))
+	files := map[string]string{
+		"world.aliengame":             "include continents/europe.aliengame as Europe\nIstanbul west=Europe-Paris\n",
+		"continents/europe.aliengame": "Paris north=London\n",
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	cmd := New()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"map", "analyze", "-m", filepath.Join(dir, "world.aliengame"), "--from", "Istanbul", "--to", "London"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, buf.String(), "shortest path        Istanbul -> Europe-Paris -> London")
+}
@@ -0,0 +1,92 @@
+package aliengamecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/spf13/cobra"
+)
+
+var mergeOutputPath string
+
+// newMapDiffCmd returns a command to show the difference between two maps.
+func newMapDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [before] [after]",
+		Short: "show the added and removed cities and neighbor links between two map files",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mapDiffHandler(args[0], args[1], output, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", outputText, "output format of the diff (text|json)")
+	return cmd
+}
+
+// mapDiffHandler prints the difference between the map files to w in the
+// given output format.
+func mapDiffHandler(beforePath, afterPath, output string, w io.Writer) error {
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("unknown output format %q", output)
+	}
+	before, err := parseMapFile(beforePath)
+	if err != nil {
+		return err
+	}
+	after, err := parseMapFile(afterPath)
+	if err != nil {
+		return err
+	}
+	d := aliengame.DiffMaps(before, after)
+	if output == outputJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+	return aliengame.PrintMapDiff(w, d)
+}
+
+// newMapMergeCmd returns a command to merge the changes made on the same map.
+func newMapMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge [base] [ours] [theirs]",
+		Short: "three-way merge the map files ours and theirs edited from the base map",
+		Args:  cobra.ExactArgs(3),
+		// merge conflicts are not usage errors.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mapMergeHandler(args[0], args[1], args[2], mergeOutputPath, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&mergeOutputPath, "write", "w", "", "path to save the merged map instead of printing it")
+	return cmd
+}
+
+// mapMergeHandler merges the map files and prints the merged map to w, or
+// saves it to outputPath if it is set. conflicts are printed to w.
+func mapMergeHandler(basePath, oursPath, theirsPath, outputPath string, w io.Writer) error {
+	var maps []aliengame.Map
+	for _, path := range []string{basePath, oursPath, theirsPath} {
+		mp, err := parseMapFile(path)
+		if err != nil {
+			return err
+		}
+		maps = append(maps, mp)
+	}
+	merged, err := aliengame.MergeMaps(maps[0], maps[1], maps[2])
+	if conflictErr, ok := err.(*aliengame.MergeConflictError); ok {
+		for _, conflict := range conflictErr.Conflicts {
+			fmt.Fprintf(w, "CONFLICT %s\n", conflict)
+		}
+		return fmt.Errorf("cannot merge with %d conflicts", len(conflictErr.Conflicts))
+	}
+	if err != nil {
+		return err
+	}
+	if outputPath != "" {
+		return saveMapFile(outputPath, merged)
+	}
+	return aliengame.PrintMap(w, merged)
+}
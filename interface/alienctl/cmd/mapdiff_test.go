@@ -0,0 +1,70 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapDiffAndMergeCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mapdiff")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	run := func(args ...string) (string, error) {
+		cmd := New()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs(append([]string{"map"}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	base := write("base", "Foo north=Bar west=Baz\n")
+	ours := write("ours", "Foo north=Bar west=Baz east=Qux\n")
+	theirs := write("theirs", "Foo north=Bar\nBar north=Yee\n")
+	conflicting := write("conflicting", "Foo north=Bar west=Baz east=Yee\n")
+
+	out, err := run("diff", base, theirs)
+	require.NoError(t, err)
+	require.Equal(t, `- Baz
++ Yee
+- Baz east=Foo
+- Foo west=Baz
++ Bar north=Yee
++ Yee south=Bar
+`, out)
+
+	out, err = run("diff", base, ours, "-o", "json")
+	require.NoError(t, err)
+	require.Contains(t, out, `"addedCities": [
+    "Qux"
+  ]`)
+
+	out, err = run("merge", base, ours, theirs)
+	require.NoError(t, err)
+	require.Equal(t, `Bar north=Yee south=Foo
+Foo east=Qux north=Bar
+Qux west=Foo
+Yee south=Bar
+`, out)
+
+	merged := filepath.Join(dir, "merged")
+	_, err = run("merge", base, ours, theirs, "-w", merged)
+	require.NoError(t, err)
+	data, err := ioutil.ReadFile(merged)
+	require.NoError(t, err)
+	require.Equal(t, out, string(data))
+
+	out, err = run("merge", base, ours, conflicting)
+	require.Error(t, err)
+	require.Contains(t, out, "CONFLICT Foo east: ours Qux, theirs Yee\n")
+}
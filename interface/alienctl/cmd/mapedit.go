@@ -0,0 +1,179 @@
+package aliengamecmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/spf13/cobra"
+)
+
+// mapEditHelp lists the commands of the map editor.
+const mapEditHelp = `commands:
+  add <city>...                  add cities
+  remove <city>...               remove cities and their roads
+  connect <city> <dir> <city>    build a road and the road back
+  disconnect <city> <dir>        remove a road and the road back
+  show                           print the map
+  save [path]                    save the map
+  help                           print the commands
+  quit                           quit the editor
+`
+
+// newMapEditCmd returns a command to build or modify a map file interactively.
+func newMapEditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "edit [path]",
+		Short: "build or modify a map file interactively, the file is created if it does not exist",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mapEditHandler(args[0], cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
+
+// mapEditHandler runs the map editor for the map file at path. commands are
+// read from r line by line and the results are printed to w.
+func mapEditHandler(path string, r io.Reader, w io.Writer) error {
+	mp, err := parseMapFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		mp = make(aliengame.Map)
+		fmt.Fprintf(w, "new map %s\n", path)
+	case err != nil:
+		return err
+	default:
+		fmt.Fprintf(w, "editing %s with %d cities\n", path, len(mp))
+	}
+	fmt.Fprint(w, "type help to list the commands\n")
+
+	var (
+		scanner = bufio.NewScanner(r)
+		// unsaved is true when the map has changes that are not saved.
+		unsaved bool
+		// quitting is true when quit is asked with unsaved changes once.
+		quitting bool
+	)
+	for {
+		fmt.Fprint(w, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(w)
+			return scanner.Err()
+		}
+		args := strings.Fields(scanner.Text())
+		if len(args) == 0 {
+			continue
+		}
+		if args[0] == "quit" || args[0] == "exit" {
+			if !unsaved || quitting {
+				return nil
+			}
+			quitting = true
+			fmt.Fprint(w, "there are unsaved changes, quit again to discard them\n")
+			continue
+		}
+		quitting = false
+		changed, err := runMapEditCommand(mp, args, path, w)
+		if err != nil {
+			fmt.Fprintf(w, "error: %s\n", err)
+			continue
+		}
+		switch {
+		case args[0] == "save":
+			unsaved = false
+		case changed:
+			unsaved = true
+		}
+	}
+}
+
+// runMapEditCommand runs a command of the map editor on mp. changed is true
+// when the map is modified by the command.
+func runMapEditCommand(mp aliengame.Map, args []string, path string, w io.Writer) (changed bool, err error) {
+	name, args := args[0], args[1:]
+	switch name {
+	case "add":
+		if len(args) == 0 {
+			return false, fmt.Errorf("usage: add <city>...")
+		}
+		for _, city := range args {
+			if err := mp.AddCity(city); err != nil {
+				return changed, err
+			}
+			changed = true
+		}
+		return changed, nil
+	case "remove":
+		if len(args) == 0 {
+			return false, fmt.Errorf("usage: remove <city>...")
+		}
+		for _, city := range args {
+			if err := mp.RemoveCity(city); err != nil {
+				return changed, err
+			}
+			changed = true
+		}
+		return changed, nil
+	case "connect":
+		if len(args) != 3 {
+			return false, fmt.Errorf("usage: connect <city> <dir> <city>")
+		}
+		direction, ok := compass.ParseDirection(args[1])
+		if !ok {
+			return false, fmt.Errorf("invalid direction %q", args[1])
+		}
+		return true, mp.Connect(args[0], direction, args[2])
+	case "disconnect":
+		if len(args) != 2 {
+			return false, fmt.Errorf("usage: disconnect <city> <dir>")
+		}
+		direction, ok := compass.ParseDirection(args[1])
+		if !ok {
+			return false, fmt.Errorf("invalid direction %q", args[1])
+		}
+		return true, mp.Disconnect(args[0], direction)
+	case "show":
+		return false, aliengame.PrintMap(w, mp)
+	case "save":
+		if len(args) > 1 {
+			return false, fmt.Errorf("usage: save [path]")
+		}
+		if len(args) == 1 {
+			path = args[0]
+		}
+		if err := saveMapFile(path, mp); err != nil {
+			return false, err
+		}
+		fmt.Fprintf(w, "saved %d cities to %s\n", len(mp), path)
+		return false, nil
+	case "help":
+		fmt.Fprint(w, mapEditHelp)
+		return false, nil
+	}
+	return false, fmt.Errorf("unknown command %q, type help to list the commands", name)
+}
+
+// saveMapFile saves mp to the file at path in the map defination format. the
+// map must be describable by the format, so every city needs at least a road.
+func saveMapFile(path string, mp aliengame.Map) error {
+	if len(mp) == 0 {
+		return fmt.Errorf("there must be at least one city in the map")
+	}
+	for _, city := range mp {
+		if len(city.Neighbors) == 0 {
+			return fmt.Errorf("city %q has no roads", city.Name)
+		}
+	}
+	var buf bytes.Buffer
+	if err := aliengame.PrintMap(&buf, mp); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
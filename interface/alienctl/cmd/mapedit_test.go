@@ -0,0 +1,74 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapEditCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mapedit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "new.aliengame")
+
+	edit := func(path string, commands ...string) string {
+		cmd := New()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetIn(strings.NewReader(strings.Join(commands, "\n")))
+		cmd.SetArgs([]string{"map", "edit", path})
+		require.NoError(t, cmd.Execute())
+		return buf.String()
+	}
+
+	out := edit(path,
+		"add Foo Bar Baz",
+		"connect Foo north Bar",
+		"save",
+		"connect Baz east Foo",
+		"connect Baz east Bar",
+		"disconnect Foo up",
+		"jump",
+		"quit",
+		"save",
+		"quit",
+	)
+	require.Contains(t, out, "new map "+path)
+	require.Contains(t, out, `error: city "Baz" has no roads`)
+	require.Contains(t, out, `error: East of "Baz" is already connected to "Foo"`)
+	require.Contains(t, out, `error: invalid direction "up"`)
+	require.Contains(t, out, `error: unknown command "jump"`)
+	require.Contains(t, out, "there are unsaved changes")
+	require.Contains(t, out, "saved 3 cities to "+path)
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `Bar south=Foo
+Baz east=Foo
+Foo north=Bar west=Baz
+`, string(data))
+
+	// modify the existing map and save it to another file.
+	copyPath := filepath.Join(dir, "copy.aliengame")
+	out = edit(path,
+		"remove Baz",
+		"disconnect Bar south",
+		"add Qux",
+		"connect Qux west Bar",
+		"connect Qux east Foo",
+		"show",
+		"save "+copyPath,
+	)
+	require.Contains(t, out, "editing "+path+" with 3 cities")
+	data, err = ioutil.ReadFile(copyPath)
+	require.NoError(t, err)
+	require.Equal(t, `Bar east=Qux
+Foo west=Qux
+Qux east=Foo west=Bar
+`, string(data))
+}
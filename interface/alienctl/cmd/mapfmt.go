@@ -0,0 +1,126 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/spf13/cobra"
+)
+
+// mapFileExt is the extension of the map files.
+const mapFileExt = ".aliengame"
+
+// fmtConfig configures the map formatter.
+type fmtConfig struct {
+	// list lists the files that are not formatted instead of printing them.
+	list bool
+
+	// diff prints the diffs of the formatting instead of the formatted files.
+	diff bool
+
+	// write writes the formatted files in place instead of printing them.
+	write bool
+}
+
+var mapFmt fmtConfig
+
+// newMapFmtCmd returns a command to format map files canonically.
+func newMapFmtCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fmt [path...]",
+		Short: "format map files, or *" + mapFileExt + " files in directories, canonically",
+		Long: "fmt formats map files canonically. cities and directions are sorted, directions are " +
+			"lowercased and the links that would be inferred from the neighbors are removed. " +
+			"the standard input is formatted when no path is given.",
+		// invalid map files are not usage errors.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return mapFmtHandler(args, mapFmt, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().BoolVarP(&mapFmt.list, "list", "l", false, "list the files whose formatting differs")
+	cmd.Flags().BoolVarP(&mapFmt.diff, "diff", "d", false, "print the diffs of the formatting")
+	cmd.Flags().BoolVarP(&mapFmt.write, "write", "w", false, "write the formatted files in place")
+	return cmd
+}
+
+// mapFmtHandler formats the map files in paths, or r when there are no paths,
+// and prints the results to w and the files that cannot be formatted to errW.
+func mapFmtHandler(paths []string, config fmtConfig, r io.Reader, w, errW io.Writer) error {
+	if len(paths) == 0 {
+		if config.write {
+			return fmt.Errorf("cannot use -w with the standard input")
+		}
+		src, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		return formatMapFile("<standard input>", src, config, w)
+	}
+	var failed int
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// files given as arguments are formatted with any extension.
+			if d.IsDir() || (path != root && filepath.Ext(path) != mapFileExt) {
+				return nil
+			}
+			src, err := os.ReadFile(path)
+			if err == nil {
+				err = formatMapFile(path, src, config, w)
+			}
+			if err != nil {
+				failed++
+				fmt.Fprintf(errW, "%s: %s\n", path, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d files cannot be formatted", failed)
+	}
+	return nil
+}
+
+// formatMapFile formats the content of the map file at path and prints or
+// writes the result as configured.
+func formatMapFile(path string, src []byte, config fmtConfig, w io.Writer) error {
+	var buf bytes.Buffer
+	if err := aliengame.FormatMap(bytes.NewReader(src), &buf); err != nil {
+		return err
+	}
+	res := buf.Bytes()
+	if !config.list && !config.diff && !config.write {
+		_, err := w.Write(res)
+		return err
+	}
+	if bytes.Equal(src, res) {
+		return nil
+	}
+	if config.list {
+		fmt.Fprintln(w, path)
+	}
+	if config.write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, res, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if config.diff {
+		printUnifiedDiff(w, path+".orig", path, string(src), string(res))
+	}
+	return nil
+}
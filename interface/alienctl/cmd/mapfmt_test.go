@@ -0,0 +1,70 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFmtCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mapfmt")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	unformatted := filepath.Join(dir, "a.aliengame")
+	formatted := filepath.Join(dir, "b.aliengame")
+	invalid := filepath.Join(dir, "c.txt")
+	require.NoError(t, ioutil.WriteFile(unformatted, []byte("Foo North=Bar east=Baz\nBar south=Foo\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(formatted, []byte("Bar south=Foo\nFoo east=Baz\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(invalid, []byte("Foo up=Bar\n"), 0644))
+
+	var errBuf bytes.Buffer
+	run := func(stdin string, args ...string) (string, error) {
+		cmd := New()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		errBuf.Reset()
+		cmd.SetErr(&errBuf)
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SetArgs(append([]string{"map", "fmt"}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run("Foo north=Bar west=Baz\n")
+	require.NoError(t, err)
+	require.Equal(t, "Foo north=Bar west=Baz\n", out)
+
+	out, err = run("", "-l", dir)
+	require.NoError(t, err)
+	require.Equal(t, unformatted+"\n", out)
+
+	out, err = run("", "-d", unformatted)
+	require.NoError(t, err)
+	require.Equal(t, `--- `+unformatted+`.orig
++++ `+unformatted+`
+@@ -1,2 +1,2 @@
+-Foo North=Bar east=Baz
+ Bar south=Foo
++Foo east=Baz
+`, out)
+
+	// files given as arguments are formatted with any extension.
+	out, err = run("", "-l", dir, invalid)
+	require.Error(t, err)
+	require.NotContains(t, out, "invalid direction")
+	require.Contains(t, errBuf.String(), invalid+`: invalid direction "up" found at line '1'`)
+
+	_, err = run("", "-w", dir)
+	require.NoError(t, err)
+	data, err := ioutil.ReadFile(unformatted)
+	require.NoError(t, err)
+	require.Equal(t, "Bar south=Foo\nFoo east=Baz\n", string(data))
+	out, err = run("", "-l", dir)
+	require.NoError(t, err)
+	require.Empty(t, out)
+}
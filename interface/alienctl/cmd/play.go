@@ -0,0 +1,375 @@
+package aliengamecmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	// minDelay and maxDelay are the limits of the delay between iterations
+	// when speed is changed in the tui.
+	minDelay = 25 * time.Millisecond
+	maxDelay = 5 * time.Second
+
+	// maxTUIEvents is the number of the most recent events shown in the tui.
+	maxTUIEvents = 20
+
+	// localPlayer is the name of the player at the terminal.
+	localPlayer = "you"
+)
+
+var (
+	playTUI     bool
+	playDelay   time.Duration
+	playControl []string
+)
+
+// newPlayCmd returns a command to watch a game step by step.
+func newPlayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "play",
+		Short: "watch a game step by step",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mp, err := parseMapFile(mapFilePath)
+			if err != nil {
+				return err
+			}
+			if len(playControl) > 0 {
+				if playTUI {
+					return fmt.Errorf("aliens cannot be controlled in the tui")
+				}
+				return playerHandler(cmd.Context(), mp, alienCount, playControl, cmd.InOrStdin(), cmd.OutOrStdout())
+			}
+			if !playTUI {
+				return playHandler(cmd.Context(), mp, alienCount, playDelay, cmd.OutOrStdout())
+			}
+			return playTUIHandler(cmd.Context(), mp, alienCount, playDelay)
+		},
+	}
+	cmd.Flags().StringVarP(&mapFilePath, "map-file", "m", "", "path to the map file (required)")
+	cmd.Flags().IntVarP(&alienCount, "alien-count", "a", 0, "number of aliens to spawn (required)")
+	cmd.Flags().BoolVar(&playTUI, "tui", false, "watch the game in an interactive terminal ui")
+	cmd.Flags().DurationVarP(&playDelay, "delay", "d", 500*time.Millisecond, "delay between iterations")
+	cmd.Flags().StringSliceVar(&playControl, "control", nil, "names of the aliens to control by moving them each turn")
+	cmd.MarkFlagRequired("map-file")
+	cmd.MarkFlagRequired("alien-count")
+	return cmd
+}
+
+// playHandler plays the game with a delay between iterations and prints the
+// events of each iteration to w.
+func playHandler(ctx context.Context, mp aliengame.Map, alienCount int, delay time.Duration, w io.Writer) error {
+	events := make(chan aliengame.Event)
+	world := aliengame.New(mp, events)
+	world.SpawnAlien(alienCount)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for event := range events {
+			fmt.Fprintf(w, "e>%s\n", event)
+		}
+	}()
+	result, _ := world.Run(ctx, aliengame.RunOptions{StepDelay: delay})
+	world.End()
+	wg.Wait()
+	fmt.Fprintf(w, "\nGAME OVER: %s after %d iterations\n", result.Reason, result.Iterations)
+	return nil
+}
+
+// playerHandler plays the game where the user controls the aliens in
+// alienNames. the user is asked for the direction of each alien that can move
+// before each iteration, aliens move randomly when no direction is given.
+func playerHandler(ctx context.Context, mp aliengame.Map, alienCount int, alienNames []string, r io.Reader, w io.Writer) error {
+	// do not wait for moves since they are submitted before each iteration.
+	world := aliengame.New(mp, nil, aliengame.WithTurnTimeout(0))
+	world.SpawnAlien(alienCount)
+	defer world.End()
+	if err := world.AddPlayer(localPlayer, alienNames...); err != nil {
+		return err
+	}
+	lines := bufio.NewScanner(r)
+	var result aliengame.RunResult
+	for {
+		mp := world.Map()
+		for _, alien := range world.Aliens() {
+			if !isControlled(alien.Name, alienNames) || alien.IsTrapped || len(mp[alien.CityName].Neighbors) == 0 {
+				continue
+			}
+			for {
+				fmt.Fprintf(w, "%s is in %s, move to (%s): ", alien.Name, alien.CityName, neighborList(mp[alien.CityName]))
+				if !lines.Scan() {
+					return lines.Err()
+				}
+				line := strings.TrimSpace(lines.Text())
+				if line == "" {
+					break
+				}
+				direction, ok := compass.ParseDirection(line)
+				if !ok {
+					fmt.Fprintf(w, "%q is not a direction\n", line)
+					continue
+				}
+				if err := world.Move(localPlayer, alien.Name, direction); err != nil {
+					fmt.Fprintln(w, err)
+					continue
+				}
+				break
+			}
+		}
+		var err error
+		events := world.Collect(func() {
+			result, err = world.Run(ctx, aliengame.RunOptions{MaxIterations: 1})
+		})
+		for _, event := range events {
+			fmt.Fprintf(w, "e>%s\n", event)
+		}
+		if err != nil || result.Reason != aliengame.IterationLimitReached {
+			break
+		}
+	}
+	fmt.Fprintf(w, "\nGAME OVER: %s after %d iterations\n\nSCORES\n", result.Reason, result.Iterations)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, score := range world.Scores() {
+		fmt.Fprintf(tw, "%s\t%d points\t%d survived iterations\t%d cities destroyed\t%s\n", score.Player,
+			score.Points, score.SurvivedIterations, score.CitiesDestroyed, strings.Join(score.Survivors, " "))
+	}
+	return tw.Flush()
+}
+
+func isControlled(alienName string, alienNames []string) bool {
+	for _, name := range alienNames {
+		if name == alienName {
+			return true
+		}
+	}
+	return false
+}
+
+// neighborList lists the neighbors of city in the compass order.
+func neighborList(city *aliengame.City) string {
+	var neighbors []string
+	for _, direction := range compass.Directions {
+		if neighborName, ok := city.Neighbors[direction]; ok {
+			neighbors = append(neighbors, fmt.Sprintf("%s=%s", strings.ToLower(string(direction)), neighborName))
+		}
+	}
+	return strings.Join(neighbors, " ")
+}
+
+// playTUIHandler plays the game in an interactive terminal ui.
+func playTUIHandler(ctx context.Context, mp aliengame.Map, alienCount int, delay time.Duration) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("tui needs an interactive terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	// read the pressed keys.
+	keys := make(chan byte)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			if _, err := os.Stdin.Read(buf); err != nil {
+				close(keys)
+				return
+			}
+			keys <- buf[0]
+		}
+	}()
+	t := newTUI(mp, alienCount, delay)
+	t.loop(ctx, keys, os.Stdout)
+	return nil
+}
+
+// tui is an interactive terminal ui to watch a game.
+type tui struct {
+	world  *aliengame.World
+	layout map[string]aliengame.Point
+
+	// eventLines are the most recent events.
+	eventLines []string
+
+	iteration int
+
+	// jump is the typed iteration number to jump to.
+	jump string
+
+	paused   bool
+	gameOver bool
+	delay    time.Duration
+}
+
+// newTUI creates a new tui with a new game on mp.
+func newTUI(mp aliengame.Map, alienCount int, delay time.Duration) *tui {
+	world := aliengame.New(mp, nil, aliengame.WithHistory(0))
+	world.SpawnAlien(alienCount)
+	return &tui{
+		world:  world,
+		layout: mp.Layout(),
+		delay:  delay,
+	}
+}
+
+// loop runs the tui until the user quits or ctx is cancelled. frames are
+// rendered to w.
+func (t *tui) loop(ctx context.Context, keys <-chan byte, w io.Writer) {
+	t.render(w)
+	for {
+		var tick <-chan time.Time
+		if !t.paused && !t.gameOver {
+			tick = time.After(t.delay)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case key, ok := <-keys:
+			if !ok || !t.handleKey(key) {
+				return
+			}
+		case <-tick:
+			t.step()
+		}
+		t.render(w)
+	}
+}
+
+// handleKey handles a pressed key. quit is false when the user wants to quit.
+func (t *tui) handleKey(key byte) (ok bool) {
+	switch key {
+	case 'q', 3: // 3 is Ctrl-C in raw mode.
+		return false
+	case ' ', 'p':
+		t.paused = !t.paused
+	case 'n', 's':
+		if t.paused {
+			t.step()
+		}
+	case 'b':
+		t.paused = true
+		t.jumpTo(t.iteration - 1)
+	case 'g':
+		if iteration, err := strconv.Atoi(t.jump); err == nil {
+			t.paused = true
+			t.jumpTo(iteration)
+		}
+		t.jump = ""
+	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		t.jump += string(key)
+	case '+':
+		if t.delay /= 2; t.delay < minDelay {
+			t.delay = minDelay
+		}
+	case '-':
+		if t.delay *= 2; t.delay > maxDelay {
+			t.delay = maxDelay
+		}
+	}
+	return true
+}
+
+// step resumes the world for one iteration and collects the emitted events.
+func (t *tui) step() {
+	if t.gameOver {
+		return
+	}
+	t.iteration++
+	events := t.world.Collect(func() {
+		t.gameOver = !t.world.Resume()
+	})
+	for _, event := range events {
+		t.addEventLine(fmt.Sprintf("%d: %s", t.iteration, strings.Replace(event.String(), "\n\t", " ", -1)))
+	}
+}
+
+// jumpTo restores the game to the state right after the iteration.
+func (t *tui) jumpTo(iteration int) {
+	if err := t.world.JumpTo(iteration); err != nil {
+		t.addEventLine(err.Error())
+		return
+	}
+	t.iteration = iteration
+	t.gameOver = t.world.Ended()
+	t.addEventLine(fmt.Sprintf("%d: jumped to iteration %d", iteration, iteration))
+}
+
+// addEventLine adds a line to the most recent events.
+func (t *tui) addEventLine(line string) {
+	t.eventLines = append(t.eventLines, line)
+	if len(t.eventLines) > maxTUIEvents {
+		t.eventLines = t.eventLines[1:]
+	}
+}
+
+// render renders a frame of the tui to w.
+func (t *tui) render(w io.Writer) {
+	status := "running"
+	switch {
+	case t.gameOver:
+		status = "game over"
+	case t.paused:
+		status = "paused"
+	}
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J") // clear the screen.
+	fmt.Fprintf(&b, "iteration %d | %s | delay %s | aliens %d\r\n", t.iteration, status, t.delay,
+		len(t.world.Aliens()))
+	b.WriteString("keys: space pause, n step, b step back, <number>g go to iteration, + faster, - slower, q quit\r\n")
+	first, last := t.world.History()
+	fmt.Fprintf(&b, "history: %d-%d", first, last)
+	if t.jump != "" {
+		fmt.Fprintf(&b, " | go to %s", t.jump)
+	}
+	b.WriteString("\r\n\r\n")
+	grid := t.grid()
+	lines := len(grid)
+	if len(t.eventLines) > lines {
+		lines = len(t.eventLines)
+	}
+	width := 0
+	for _, line := range grid {
+		if n := len([]rune(line)); n > width {
+			width = n
+		}
+	}
+	for i := 0; i < lines; i++ {
+		var gridLine, eventLine string
+		if i < len(grid) {
+			gridLine = grid[i]
+		}
+		if i < len(t.eventLines) {
+			eventLine = t.eventLines[i]
+		}
+		pad := width - len([]rune(gridLine))
+		fmt.Fprintf(&b, "%s%s | %s\r\n", gridLine, strings.Repeat(" ", pad), eventLine)
+	}
+	io.WriteString(w, b.String())
+}
+
+// grid draws the current state of the map on the layout of the initial map
+// with the aliens in the cities.
+func (t *tui) grid() []string {
+	var buf strings.Builder
+	aliengame.RenderMap(&buf, t.world.Map(), aliengame.RenderOptions{
+		Layout: t.layout,
+		Aliens: t.world.Aliens(),
+	})
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+}
@@ -0,0 +1,114 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayCmd(t *testing.T) {
+	cmd := New()
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetArgs([]string{"play", "-m", testmapPath, "-a", "2", "-d", "0"})
+	require.NoError(t, cmd.Execute())
+	require.True(t, strings.Contains(buf.String(), "GAME OVER"))
+}
+
+func TestTUI(t *testing.T) {
+	mp := aliengame.Map{
+		"Foo": &aliengame.City{Name: "Foo", Neighbors: map[compass.Direction]string{
+			compass.East:  "Bar",
+			compass.South: "Baz",
+		}},
+		"Bar": &aliengame.City{Name: "Bar", Neighbors: map[compass.Direction]string{
+			compass.West: "Foo",
+		}},
+		"Baz": &aliengame.City{Name: "Baz", Neighbors: map[compass.Direction]string{
+			compass.North: "Foo",
+		}},
+	}
+	tu := newTUI(mp, 0, time.Second)
+	require.Equal(t, []string{
+		"Foo ── Bar",
+		"│",
+		"Baz",
+	}, tu.grid())
+
+	// speed is changed within the limits.
+	for i := 0; i < 10; i++ {
+		require.True(t, tu.handleKey('-'))
+	}
+	require.Equal(t, maxDelay, tu.delay)
+	for i := 0; i < 20; i++ {
+		require.True(t, tu.handleKey('+'))
+	}
+	require.Equal(t, minDelay, tu.delay)
+
+	// step while paused, then quit.
+	keys := make(chan byte, 3)
+	keys <- ' '
+	keys <- 'n'
+	keys <- 'q'
+	var buf bytes.Buffer
+	tu.delay = time.Hour
+	tu.loop(context.Background(), keys, &buf)
+	require.True(t, tu.paused)
+	require.True(t, tu.gameOver)
+	require.Equal(t, 1, tu.iteration)
+	require.True(t, strings.Contains(buf.String(), "iteration 1 | game over"))
+
+	// step back and jump to the last iteration again.
+	require.True(t, tu.handleKey('b'))
+	require.Equal(t, 0, tu.iteration)
+	require.False(t, tu.gameOver)
+	require.True(t, tu.handleKey('1'))
+	require.True(t, tu.handleKey('g'))
+	require.Equal(t, 1, tu.iteration)
+	require.True(t, tu.gameOver)
+	require.True(t, tu.handleKey('9'))
+	require.True(t, tu.handleKey('g'))
+	require.Equal(t, 1, tu.iteration)
+}
+
+// newlines is an endless stream of empty lines.
+type newlines struct{}
+
+func (newlines) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = '\n'
+	}
+	return len(p), nil
+}
+
+func TestPlayerHandler(t *testing.T) {
+	mp, err := aliengame.ParseMap(strings.NewReader("B west=A east=C"))
+	require.NoError(t, err)
+	require.NoError(t, aliengame.CraftMap(mp))
+	// invalid moves are asked again, then aliens move randomly.
+	input := io.MultiReader(strings.NewReader("up\nnorth\n"), newlines{})
+	var buf bytes.Buffer
+	require.NoError(t, playerHandler(context.Background(), mp, 2, []string{"A1"}, input, &buf))
+	out := buf.String()
+	require.Contains(t, out, "A1 is in ")
+	require.Contains(t, out, `"up" is not a direction`)
+	require.Contains(t, out, "has no neighbor at North")
+	require.Contains(t, out, "GAME OVER")
+	require.Contains(t, out, "SCORES\nyou")
+
+	require.Error(t, playerHandler(context.Background(), mp, 1, []string{"A2"}, input, &buf))
+}
+
+func TestPlayCmdControl(t *testing.T) {
+	cmd := New()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"play", "-m", testmapPath, "-a", "2", "--tui", "--control", "A1"})
+	require.Error(t, cmd.Execute())
+}
@@ -0,0 +1,108 @@
+package aliengamecmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/spf13/cobra"
+)
+
+// newScenarioCmd returns a command to work with scenario files.
+func newScenarioCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scenario",
+		Short: "work with scenario files",
+	}
+	cmd.AddCommand(newScenarioRunCmd())
+	return cmd
+}
+
+// newScenarioRunCmd returns a command to run scenarios and check their
+// expectations.
+func newScenarioRunCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "run [path...]",
+		Short: "run scenario files, or *.json files in directories, and report pass/fail",
+		Args:  cobra.MinimumNArgs(1),
+		// failing scenarios are not usage errors.
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return scenarioRunHandler(cmd.Context(), args, cmd.OutOrStdout())
+		},
+	}
+}
+
+// scenarioRunHandler runs the scenarios in paths and prints a report to w.
+// error is not nil when at least one of the scenarios fails.
+func scenarioRunHandler(ctx context.Context, paths []string, w io.Writer) error {
+	files, err := scenarioFiles(paths)
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, file := range files {
+		failures, err := runScenarioFile(ctx, file)
+		switch {
+		case err != nil:
+			failed++
+			fmt.Fprintf(w, "ERROR %s\n\t%s\n", file, err)
+		case len(failures) > 0:
+			failed++
+			fmt.Fprintf(w, "FAIL  %s\n", file)
+			for _, failure := range failures {
+				fmt.Fprintf(w, "\t%s\n", failure)
+			}
+		default:
+			fmt.Fprintf(w, "PASS  %s\n", file)
+		}
+	}
+	fmt.Fprintf(w, "\n%d passed, %d failed\n", len(files)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d scenarios failed", failed, len(files))
+	}
+	return nil
+}
+
+// scenarioFiles lists the scenario files in paths. directories are expanded to
+// the *.json files in them.
+func scenarioFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no scenario files in %q", path)
+		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+func runScenarioFile(ctx context.Context, path string) (failures []string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	s, err := aliengame.ParseScenario(f)
+	if err != nil {
+		return nil, err
+	}
+	return s.Run(ctx)
+}
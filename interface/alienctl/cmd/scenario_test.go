@@ -0,0 +1,49 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testScenariosPath = "../../../aliengame/testdata/scenarios"
+
+func TestScenarioRunCmd(t *testing.T) {
+	run := func(args ...string) (string, error) {
+		cmd := New()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(append([]string{"scenario", "run"}, args...))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	out, err := run(testScenariosPath)
+	require.NoError(t, err)
+	require.Contains(t, out, "PASS  "+filepath.Join(testScenariosPath, "swap.json"))
+	require.Contains(t, out, " 0 failed")
+
+	dir, err := ioutil.TempDir("", "scenarios")
+	require.NoError(t, err)
+	failing := filepath.Join(dir, "failing.json")
+	require.NoError(t, ioutil.WriteFile(failing, []byte(`{
+  "map": ["Foo east=Bar"],
+  "spawns": {"A1": "Foo", "A2": "Foo"},
+  "expect": {"survivors": ["A1"]}
+}`), 0644))
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, ioutil.WriteFile(invalid, []byte(`{"map": ["Foo up=Bar"]}`), 0644))
+
+	out, err = run(dir, filepath.Join(testScenariosPath, "swap.json"))
+	require.Error(t, err)
+	require.Contains(t, out, "FAIL  "+failing+"\n\tsurvivors: expected [A1], got []")
+	require.Contains(t, out, "ERROR "+invalid)
+	require.Contains(t, out, "\n1 passed, 2 failed\n")
+
+	_, err = run(filepath.Join(dir, "none"))
+	require.Error(t, err)
+}
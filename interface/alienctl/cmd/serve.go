@@ -0,0 +1,45 @@
+package aliengamecmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/ilgooz/aliengame/interface/alienhttp"
+	"github.com/spf13/cobra"
+)
+
+var serveAddr string
+
+// newServeCmd returns a command to serve games over HTTP.
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "serve games over HTTP",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l, err := net.Listen("tcp", serveAddr)
+			if err != nil {
+				return err
+			}
+			return serveHandler(cmd.Context(), l, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&serveAddr, "addr", ":8080", "address to listen on")
+	return cmd
+}
+
+// serveHandler serves games on l until ctx is cancelled.
+func serveHandler(ctx context.Context, l net.Listener, w io.Writer) error {
+	server := &http.Server{Handler: alienhttp.New()}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	fmt.Fprintf(w, "serving games on %s\n", l.Addr())
+	if err := server.Serve(l); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	var buf bytes.Buffer
+	errC := make(chan error)
+	go func() { errC <- serveHandler(ctx, l, &buf) }()
+
+	res, err := http.Post("http://"+l.Addr().String()+"/games?aliens=1", "text/plain",
+		strings.NewReader("Foo north=Bar"))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	cancel()
+	require.NoError(t, <-errC)
+	require.True(t, strings.HasPrefix(buf.String(), "serving games on 127.0.0.1:"))
+}
@@ -0,0 +1,245 @@
+package aliengamecmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/spf13/cobra"
+)
+
+const outputCSV = "csv"
+
+var (
+	simRuns     int
+	simParallel int
+	simSeed     int64
+)
+
+// newSimulateCmd returns a command to play the same map many times to estimate
+// the outcome of games.
+func newSimulateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate",
+		Short: "play the same map many times and aggregate the results",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return simulateHandler(cmd.Context(), mapFilePath, alienCount, simRuns, simParallel,
+				simSeed, output, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&mapFilePath, "map-file", "m", "", "path to the map file (required)")
+	cmd.Flags().IntVarP(&alienCount, "alien-count", "a", 0, "number of aliens to spawn (required)")
+	cmd.Flags().IntVarP(&simRuns, "runs", "n", 1000, "number of games to play")
+	cmd.Flags().IntVarP(&simParallel, "parallel", "p", 1, "number of games to play at the same time")
+	cmd.Flags().Int64Var(&simSeed, "seed", time.Now().UnixNano(), "seed of the first game, each next game uses the next seed")
+	cmd.Flags().StringVarP(&output, "output", "o", outputText, "output format of the simulation result (text|csv|json)")
+	cmd.MarkFlagRequired("map-file")
+	cmd.MarkFlagRequired("alien-count")
+	return cmd
+}
+
+// simulationResult is the aggregated result of many games.
+type simulationResult struct {
+	Runs                    int            `json:"runs"`
+	Seed                    int64          `json:"seed"`
+	ExpectedDestroyedCities float64        `json:"expectedDestroyedCities"`
+	GameLength              gameLength     `json:"gameLength"`
+	Cities                  []citySurvival `json:"cities"`
+}
+
+// gameLength is the distribution of game lengths in iterations.
+type gameLength struct {
+	Min       int            `json:"min"`
+	Max       int            `json:"max"`
+	Mean      float64        `json:"mean"`
+	Median    int            `json:"median"`
+	P90       int            `json:"p90"`
+	Histogram []lengthBucket `json:"histogram"`
+}
+
+// lengthBucket is the number of games ended at the same iteration.
+type lengthBucket struct {
+	Iterations int `json:"iterations"`
+	Games      int `json:"games"`
+}
+
+// citySurvival is the survival information of a city over many games.
+type citySurvival struct {
+	Name        string  `json:"name"`
+	Survived    int     `json:"survived"`
+	Probability float64 `json:"probability"`
+}
+
+// gameOutcome is the outcome of a single game in a simulation.
+type gameOutcome struct {
+	iterations      int
+	remainingCities []string
+	err             error
+}
+
+// simulateHandler plays runs number of games with a pool of parallel workers
+// and prints the aggregated result to w in the given output format.
+func simulateHandler(ctx context.Context, mapFilePath string, alienCount, runs, parallel int,
+	seed int64, output string, w io.Writer) error {
+	if output != outputText && output != outputCSV && output != outputJSON {
+		return fmt.Errorf("unknown output format %q", output)
+	}
+	if runs < 1 || parallel < 1 {
+		return fmt.Errorf("runs and parallel must be at least 1")
+	}
+	mp, err := parseMapFile(mapFilePath)
+	if err != nil {
+		return err
+	}
+	cityNames := mp.View().CityNames()
+
+	// play the games with a worker pool.
+	jobs := make(chan int64)
+	outcomes := make(chan gameOutcome)
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for seed := range jobs {
+				outcomes <- playGame(ctx, mp, alienCount, seed)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := 0; i < runs; i++ {
+			select {
+			case jobs <- seed + int64(i):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	// aggregate the outcomes.
+	var (
+		lengths   []int
+		destroyed int
+		survived  = make(map[string]int)
+	)
+	for outcome := range outcomes {
+		if outcome.err != nil {
+			err = outcome.err
+			continue
+		}
+		lengths = append(lengths, outcome.iterations)
+		destroyed += len(cityNames) - len(outcome.remainingCities)
+		for _, cityName := range outcome.remainingCities {
+			survived[cityName]++
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	result := simulationResult{
+		Runs:                    runs,
+		Seed:                    seed,
+		ExpectedDestroyedCities: float64(destroyed) / float64(runs),
+		GameLength:              newGameLength(lengths),
+	}
+	for _, cityName := range cityNames {
+		result.Cities = append(result.Cities, citySurvival{
+			Name:        cityName,
+			Survived:    survived[cityName],
+			Probability: float64(survived[cityName]) / float64(runs),
+		})
+	}
+	return printSimulationResult(w, result, output)
+}
+
+// playGame plays a single game till the end. mp is not modified since world
+// works on a copy of it.
+func playGame(ctx context.Context, mp aliengame.Map, alienCount int, seed int64) gameOutcome {
+	world := aliengame.New(mp, nil, aliengame.WithSeed(seed))
+	world.SpawnAlien(alienCount)
+	result, err := world.Run(ctx, aliengame.RunOptions{})
+	if err != nil {
+		return gameOutcome{err: err}
+	}
+	outcome := gameOutcome{iterations: result.Iterations}
+	for cityName := range world.Map() {
+		outcome.remainingCities = append(outcome.remainingCities, cityName)
+	}
+	return outcome
+}
+
+// newGameLength calculates the distribution of game lengths.
+func newGameLength(lengths []int) gameLength {
+	sort.Ints(lengths)
+	gl := gameLength{
+		Min:    lengths[0],
+		Max:    lengths[len(lengths)-1],
+		Median: lengths[len(lengths)/2],
+		P90:    lengths[len(lengths)*9/10],
+	}
+	var total int
+	for _, length := range lengths {
+		total += length
+		lb := len(gl.Histogram)
+		if lb > 0 && gl.Histogram[lb-1].Iterations == length {
+			gl.Histogram[lb-1].Games++
+			continue
+		}
+		gl.Histogram = append(gl.Histogram, lengthBucket{length, 1})
+	}
+	gl.Mean = float64(total) / float64(len(lengths))
+	return gl
+}
+
+// printSimulationResult prints result to w in the given output format.
+// csv output only includes the survival information of cities.
+func printSimulationResult(w io.Writer, result simulationResult, output string) error {
+	switch output {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"city", "survived", "probability"})
+		for _, city := range result.Cities {
+			cw.Write([]string{
+				city.Name,
+				strconv.Itoa(city.Survived),
+				strconv.FormatFloat(city.Probability, 'f', 4, 64),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "runs\t%d\n", result.Runs)
+	fmt.Fprintf(tw, "seed\t%d\n", result.Seed)
+	fmt.Fprintf(tw, "expected destroyed cities\t%.2f\n", result.ExpectedDestroyedCities)
+	gl := result.GameLength
+	fmt.Fprintf(tw, "game length\tmin %d, median %d, mean %.2f, p90 %d, max %d\n",
+		gl.Min, gl.Median, gl.Mean, gl.P90, gl.Max)
+	fmt.Fprint(tw, "\nCITY\tSURVIVED\tPROBABILITY\n")
+	for _, city := range result.Cities {
+		fmt.Fprintf(tw, "%s\t%d\t%.4f\n", city.Name, city.Survived, city.Probability)
+	}
+	return tw.Flush()
+}
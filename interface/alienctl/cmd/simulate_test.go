@@ -0,0 +1,56 @@
+package aliengamecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateCmd(t *testing.T) {
+	simulate := func(output string) string {
+		cmd := New()
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs([]string{"simulate", "-m", testmapPath, "-a", "3", "-n", "50", "-p", "4",
+			"--seed", "1", "-o", output})
+		require.NoError(t, cmd.Execute())
+		return buf.String()
+	}
+
+	var result simulationResult
+	require.NoError(t, json.Unmarshal([]byte(simulate("json")), &result))
+	require.Equal(t, 50, result.Runs)
+	require.Len(t, result.Cities, 6)
+	var games int
+	for _, bucket := range result.GameLength.Histogram {
+		games += bucket.Games
+	}
+	require.Equal(t, 50, games)
+
+	// same seed plays the same games regardless of parallelism.
+	require.Equal(t, simulate("json"), simulate("json"))
+
+	csv := simulate("csv")
+	require.True(t, strings.HasPrefix(csv, "city,survived,probability\nBar,"), csv)
+	require.Len(t, strings.Split(strings.TrimSpace(csv), "\n"), 7)
+
+	require.True(t, strings.Contains(simulate("text"), "expected destroyed cities"))
+}
+
+func TestNewGameLength(t *testing.T) {
+	require.Equal(t, gameLength{
+		Min:    1,
+		Max:    10,
+		Mean:   5,
+		Median: 3,
+		P90:    10,
+		Histogram: []lengthBucket{
+			{1, 2},
+			{3, 1},
+			{10, 2},
+		},
+	}, newGameLength([]int{10, 1, 3, 1, 10}))
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
 	aliengamecmd "github.com/ilgooz/aliengame/interface/alienctl/cmd"
 )
 
 func main() {
-	if err := aliengamecmd.New().Execute(); err != nil {
+	// cancel the game on Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := aliengamecmd.New().ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -0,0 +1,2362 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.14.0
+// source: aliengame.proto
+
+package aliengamepb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type CreateWorldRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// map is the map defination in the aliengame format.
+	Map string `protobuf:"bytes,1,opt,name=map,proto3" json:"map,omitempty"`
+	// seed makes the world deterministic when it is set.
+	Seed *wrappers.Int64Value `protobuf:"bytes,2,opt,name=seed,proto3" json:"seed,omitempty"`
+}
+
+func (x *CreateWorldRequest) Reset() {
+	*x = CreateWorldRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateWorldRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorldRequest) ProtoMessage() {}
+
+func (x *CreateWorldRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorldRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorldRequest) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateWorldRequest) GetMap() string {
+	if x != nil {
+		return x.Map
+	}
+	return ""
+}
+
+func (x *CreateWorldRequest) GetSeed() *wrappers.Int64Value {
+	if x != nil {
+		return x.Seed
+	}
+	return nil
+}
+
+type World struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// iteration is the number of times that world has been resumed.
+	Iteration int32 `protobuf:"varint,2,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	// ended is true when the world cannot be resumed anymore.
+	Ended bool `protobuf:"varint,3,opt,name=ended,proto3" json:"ended,omitempty"`
+}
+
+func (x *World) Reset() {
+	*x = World{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *World) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*World) ProtoMessage() {}
+
+func (x *World) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use World.ProtoReflect.Descriptor instead.
+func (*World) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *World) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *World) GetIteration() int32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *World) GetEnded() bool {
+	if x != nil {
+		return x.Ended
+	}
+	return false
+}
+
+type SpawnAliensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorldId string `protobuf:"bytes,1,opt,name=world_id,json=worldId,proto3" json:"world_id,omitempty"`
+	Count   int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *SpawnAliensRequest) Reset() {
+	*x = SpawnAliensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpawnAliensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpawnAliensRequest) ProtoMessage() {}
+
+func (x *SpawnAliensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpawnAliensRequest.ProtoReflect.Descriptor instead.
+func (*SpawnAliensRequest) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SpawnAliensRequest) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+func (x *SpawnAliensRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SpawnAliensResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// aliens are all the living aliens in the world.
+	Aliens []*Alien `protobuf:"bytes,1,rep,name=aliens,proto3" json:"aliens,omitempty"`
+}
+
+func (x *SpawnAliensResponse) Reset() {
+	*x = SpawnAliensResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SpawnAliensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpawnAliensResponse) ProtoMessage() {}
+
+func (x *SpawnAliensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpawnAliensResponse.ProtoReflect.Descriptor instead.
+func (*SpawnAliensResponse) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SpawnAliensResponse) GetAliens() []*Alien {
+	if x != nil {
+		return x.Aliens
+	}
+	return nil
+}
+
+type StepRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorldId string `protobuf:"bytes,1,opt,name=world_id,json=worldId,proto3" json:"world_id,omitempty"`
+}
+
+func (x *StepRequest) Reset() {
+	*x = StepRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepRequest) ProtoMessage() {}
+
+func (x *StepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepRequest.ProtoReflect.Descriptor instead.
+func (*StepRequest) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StepRequest) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+type StepResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// can_resume is false when the world has ended.
+	CanResume bool  `protobuf:"varint,1,opt,name=can_resume,json=canResume,proto3" json:"can_resume,omitempty"`
+	Iteration int32 `protobuf:"varint,2,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	// events are the events happened during the step.
+	Events []*Event `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *StepResponse) Reset() {
+	*x = StepResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StepResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepResponse) ProtoMessage() {}
+
+func (x *StepResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepResponse.ProtoReflect.Descriptor instead.
+func (*StepResponse) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StepResponse) GetCanResume() bool {
+	if x != nil {
+		return x.CanResume
+	}
+	return false
+}
+
+func (x *StepResponse) GetIteration() int32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *StepResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type RunRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorldId string `protobuf:"bytes,1,opt,name=world_id,json=worldId,proto3" json:"world_id,omitempty"`
+	// max_iterations is the max number of iterations, zero means no limit.
+	MaxIterations int32 `protobuf:"varint,2,opt,name=max_iterations,json=maxIterations,proto3" json:"max_iterations,omitempty"`
+	// timeout is the max duration of the run.
+	Timeout *duration.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// step_delay is the duration to wait between each iteration.
+	StepDelay *duration.Duration `protobuf:"bytes,4,opt,name=step_delay,json=stepDelay,proto3" json:"step_delay,omitempty"`
+}
+
+func (x *RunRequest) Reset() {
+	*x = RunRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunRequest) ProtoMessage() {}
+
+func (x *RunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
+func (*RunRequest) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RunRequest) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+func (x *RunRequest) GetMaxIterations() int32 {
+	if x != nil {
+		return x.MaxIterations
+	}
+	return 0
+}
+
+func (x *RunRequest) GetTimeout() *duration.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *RunRequest) GetStepDelay() *duration.Duration {
+	if x != nil {
+		return x.StepDelay
+	}
+	return nil
+}
+
+type RunResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Update:
+	//	*RunResponse_Event
+	//	*RunResponse_Result
+	Update isRunResponse_Update `protobuf_oneof:"update"`
+}
+
+func (x *RunResponse) Reset() {
+	*x = RunResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunResponse) ProtoMessage() {}
+
+func (x *RunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunResponse.ProtoReflect.Descriptor instead.
+func (*RunResponse) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{7}
+}
+
+func (m *RunResponse) GetUpdate() isRunResponse_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (x *RunResponse) GetEvent() *Event {
+	if x, ok := x.GetUpdate().(*RunResponse_Event); ok {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *RunResponse) GetResult() *RunResult {
+	if x, ok := x.GetUpdate().(*RunResponse_Result); ok {
+		return x.Result
+	}
+	return nil
+}
+
+type isRunResponse_Update interface {
+	isRunResponse_Update()
+}
+
+type RunResponse_Event struct {
+	Event *Event `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type RunResponse_Result struct {
+	Result *RunResult `protobuf:"bytes,2,opt,name=result,proto3,oneof"`
+}
+
+func (*RunResponse_Event) isRunResponse_Update() {}
+
+func (*RunResponse_Result) isRunResponse_Update() {}
+
+type RunResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// reason explains why the run has ended, see aliengame.EndReason.
+	Reason     string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Iterations int32  `protobuf:"varint,2,opt,name=iterations,proto3" json:"iterations,omitempty"`
+}
+
+func (x *RunResult) Reset() {
+	*x = RunResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunResult) ProtoMessage() {}
+
+func (x *RunResult) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunResult.ProtoReflect.Descriptor instead.
+func (*RunResult) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RunResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RunResult) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+type GetMapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorldId string `protobuf:"bytes,1,opt,name=world_id,json=worldId,proto3" json:"world_id,omitempty"`
+}
+
+func (x *GetMapRequest) Reset() {
+	*x = GetMapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetMapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMapRequest) ProtoMessage() {}
+
+func (x *GetMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMapRequest.ProtoReflect.Descriptor instead.
+func (*GetMapRequest) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetMapRequest) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+type SnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WorldId string `protobuf:"bytes,1,opt,name=world_id,json=worldId,proto3" json:"world_id,omitempty"`
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SnapshotRequest) GetWorldId() string {
+	if x != nil {
+		return x.WorldId
+	}
+	return ""
+}
+
+type WorldSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Iteration       int32            `protobuf:"varint,1,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	Map             *Map             `protobuf:"bytes,2,opt,name=map,proto3" json:"map,omitempty"`
+	Aliens          []*Alien         `protobuf:"bytes,3,rep,name=aliens,proto3" json:"aliens,omitempty"`
+	DestroyedCities []*DestroyedCity `protobuf:"bytes,4,rep,name=destroyed_cities,json=destroyedCities,proto3" json:"destroyed_cities,omitempty"`
+}
+
+func (x *WorldSnapshot) Reset() {
+	*x = WorldSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorldSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorldSnapshot) ProtoMessage() {}
+
+func (x *WorldSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorldSnapshot.ProtoReflect.Descriptor instead.
+func (*WorldSnapshot) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WorldSnapshot) GetIteration() int32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *WorldSnapshot) GetMap() *Map {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+func (x *WorldSnapshot) GetAliens() []*Alien {
+	if x != nil {
+		return x.Aliens
+	}
+	return nil
+}
+
+func (x *WorldSnapshot) GetDestroyedCities() []*DestroyedCity {
+	if x != nil {
+		return x.DestroyedCities
+	}
+	return nil
+}
+
+type Map struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// cities are sorted by their names.
+	Cities []*City `protobuf:"bytes,1,rep,name=cities,proto3" json:"cities,omitempty"`
+}
+
+func (x *Map) Reset() {
+	*x = Map{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Map) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Map) ProtoMessage() {}
+
+func (x *Map) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Map.ProtoReflect.Descriptor instead.
+func (*Map) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Map) GetCities() []*City {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+type City struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// neighbors are the neighbor city names by their directions: North, East,
+	// South or West.
+	Neighbors      map[string]string `protobuf:"bytes,2,rep,name=neighbors,proto3" json:"neighbors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	HasNoNeighbors bool              `protobuf:"varint,3,opt,name=has_no_neighbors,json=hasNoNeighbors,proto3" json:"has_no_neighbors,omitempty"`
+}
+
+func (x *City) Reset() {
+	*x = City{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *City) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*City) ProtoMessage() {}
+
+func (x *City) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use City.ProtoReflect.Descriptor instead.
+func (*City) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *City) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *City) GetNeighbors() map[string]string {
+	if x != nil {
+		return x.Neighbors
+	}
+	return nil
+}
+
+func (x *City) GetHasNoNeighbors() bool {
+	if x != nil {
+		return x.HasNoNeighbors
+	}
+	return false
+}
+
+type Alien struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CityName  string `protobuf:"bytes,2,opt,name=city_name,json=cityName,proto3" json:"city_name,omitempty"`
+	MoveCount int32  `protobuf:"varint,3,opt,name=move_count,json=moveCount,proto3" json:"move_count,omitempty"`
+	IsTrapped bool   `protobuf:"varint,4,opt,name=is_trapped,json=isTrapped,proto3" json:"is_trapped,omitempty"`
+}
+
+func (x *Alien) Reset() {
+	*x = Alien{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alien) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alien) ProtoMessage() {}
+
+func (x *Alien) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alien.ProtoReflect.Descriptor instead.
+func (*Alien) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Alien) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Alien) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *Alien) GetMoveCount() int32 {
+	if x != nil {
+		return x.MoveCount
+	}
+	return 0
+}
+
+func (x *Alien) GetIsTrapped() bool {
+	if x != nil {
+		return x.IsTrapped
+	}
+	return false
+}
+
+type DestroyedCity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Iteration int32    `protobuf:"varint,2,opt,name=iteration,proto3" json:"iteration,omitempty"`
+	Aliens    []string `protobuf:"bytes,3,rep,name=aliens,proto3" json:"aliens,omitempty"`
+	// external is true when the city is destroyed by an intervention.
+	External bool `protobuf:"varint,4,opt,name=external,proto3" json:"external,omitempty"`
+}
+
+func (x *DestroyedCity) Reset() {
+	*x = DestroyedCity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DestroyedCity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestroyedCity) ProtoMessage() {}
+
+func (x *DestroyedCity) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestroyedCity.ProtoReflect.Descriptor instead.
+func (*DestroyedCity) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DestroyedCity) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DestroyedCity) GetIteration() int32 {
+	if x != nil {
+		return x.Iteration
+	}
+	return 0
+}
+
+func (x *DestroyedCity) GetAliens() []string {
+	if x != nil {
+		return x.Aliens
+	}
+	return nil
+}
+
+func (x *DestroyedCity) GetExternal() bool {
+	if x != nil {
+		return x.External
+	}
+	return false
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// message is the human readable description of the event.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Types that are assignable to Event:
+	//	*Event_CityDestroyed
+	//	*Event_AlienTrapped
+	//	*Event_CityHasNoNeighbors
+	//	*Event_RoadCut
+	//	*Event_RoadBuilt
+	//	*Event_AlienKilled
+	//	*Event_AlienTeleported
+	//	*Event_CityRebuilt
+	//	*Event_CityFounded
+	Event isEvent_Event `protobuf_oneof:"event"`
+	// external is true when the event is caused by an intervention from
+	// outside of the game, see aliengame.IsExternal.
+	External bool `protobuf:"varint,5,opt,name=external,proto3" json:"external,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Event) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (m *Event) GetEvent() isEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *Event) GetCityDestroyed() *CityDestroyedEvent {
+	if x, ok := x.GetEvent().(*Event_CityDestroyed); ok {
+		return x.CityDestroyed
+	}
+	return nil
+}
+
+func (x *Event) GetAlienTrapped() *AlienTrappedEvent {
+	if x, ok := x.GetEvent().(*Event_AlienTrapped); ok {
+		return x.AlienTrapped
+	}
+	return nil
+}
+
+func (x *Event) GetCityHasNoNeighbors() *CityHasNoNeighborsEvent {
+	if x, ok := x.GetEvent().(*Event_CityHasNoNeighbors); ok {
+		return x.CityHasNoNeighbors
+	}
+	return nil
+}
+
+func (x *Event) GetRoadCut() *RoadCutEvent {
+	if x, ok := x.GetEvent().(*Event_RoadCut); ok {
+		return x.RoadCut
+	}
+	return nil
+}
+
+func (x *Event) GetRoadBuilt() *RoadBuiltEvent {
+	if x, ok := x.GetEvent().(*Event_RoadBuilt); ok {
+		return x.RoadBuilt
+	}
+	return nil
+}
+
+func (x *Event) GetAlienKilled() *AlienKilledEvent {
+	if x, ok := x.GetEvent().(*Event_AlienKilled); ok {
+		return x.AlienKilled
+	}
+	return nil
+}
+
+func (x *Event) GetAlienTeleported() *AlienTeleportedEvent {
+	if x, ok := x.GetEvent().(*Event_AlienTeleported); ok {
+		return x.AlienTeleported
+	}
+	return nil
+}
+
+func (x *Event) GetCityRebuilt() *CityRebuiltEvent {
+	if x, ok := x.GetEvent().(*Event_CityRebuilt); ok {
+		return x.CityRebuilt
+	}
+	return nil
+}
+
+func (x *Event) GetCityFounded() *CityFoundedEvent {
+	if x, ok := x.GetEvent().(*Event_CityFounded); ok {
+		return x.CityFounded
+	}
+	return nil
+}
+
+func (x *Event) GetExternal() bool {
+	if x != nil {
+		return x.External
+	}
+	return false
+}
+
+type isEvent_Event interface {
+	isEvent_Event()
+}
+
+type Event_CityDestroyed struct {
+	CityDestroyed *CityDestroyedEvent `protobuf:"bytes,2,opt,name=city_destroyed,json=cityDestroyed,proto3,oneof"`
+}
+
+type Event_AlienTrapped struct {
+	AlienTrapped *AlienTrappedEvent `protobuf:"bytes,3,opt,name=alien_trapped,json=alienTrapped,proto3,oneof"`
+}
+
+type Event_CityHasNoNeighbors struct {
+	CityHasNoNeighbors *CityHasNoNeighborsEvent `protobuf:"bytes,4,opt,name=city_has_no_neighbors,json=cityHasNoNeighbors,proto3,oneof"`
+}
+
+type Event_RoadCut struct {
+	RoadCut *RoadCutEvent `protobuf:"bytes,6,opt,name=road_cut,json=roadCut,proto3,oneof"`
+}
+
+type Event_RoadBuilt struct {
+	RoadBuilt *RoadBuiltEvent `protobuf:"bytes,7,opt,name=road_built,json=roadBuilt,proto3,oneof"`
+}
+
+type Event_AlienKilled struct {
+	AlienKilled *AlienKilledEvent `protobuf:"bytes,8,opt,name=alien_killed,json=alienKilled,proto3,oneof"`
+}
+
+type Event_AlienTeleported struct {
+	AlienTeleported *AlienTeleportedEvent `protobuf:"bytes,9,opt,name=alien_teleported,json=alienTeleported,proto3,oneof"`
+}
+
+type Event_CityRebuilt struct {
+	CityRebuilt *CityRebuiltEvent `protobuf:"bytes,10,opt,name=city_rebuilt,json=cityRebuilt,proto3,oneof"`
+}
+
+type Event_CityFounded struct {
+	CityFounded *CityFoundedEvent `protobuf:"bytes,11,opt,name=city_founded,json=cityFounded,proto3,oneof"`
+}
+
+func (*Event_CityDestroyed) isEvent_Event() {}
+
+func (*Event_AlienTrapped) isEvent_Event() {}
+
+func (*Event_CityHasNoNeighbors) isEvent_Event() {}
+
+func (*Event_RoadCut) isEvent_Event() {}
+
+func (*Event_RoadBuilt) isEvent_Event() {}
+
+func (*Event_AlienKilled) isEvent_Event() {}
+
+func (*Event_AlienTeleported) isEvent_Event() {}
+
+func (*Event_CityRebuilt) isEvent_Event() {}
+
+func (*Event_CityFounded) isEvent_Event() {}
+
+type CityDestroyedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City   string   `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Aliens []string `protobuf:"bytes,2,rep,name=aliens,proto3" json:"aliens,omitempty"`
+}
+
+func (x *CityDestroyedEvent) Reset() {
+	*x = CityDestroyedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CityDestroyedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityDestroyedEvent) ProtoMessage() {}
+
+func (x *CityDestroyedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityDestroyedEvent.ProtoReflect.Descriptor instead.
+func (*CityDestroyedEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CityDestroyedEvent) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CityDestroyedEvent) GetAliens() []string {
+	if x != nil {
+		return x.Aliens
+	}
+	return nil
+}
+
+type AlienTrappedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City  string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Alien string `protobuf:"bytes,2,opt,name=alien,proto3" json:"alien,omitempty"`
+}
+
+func (x *AlienTrappedEvent) Reset() {
+	*x = AlienTrappedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlienTrappedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlienTrappedEvent) ProtoMessage() {}
+
+func (x *AlienTrappedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlienTrappedEvent.ProtoReflect.Descriptor instead.
+func (*AlienTrappedEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AlienTrappedEvent) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *AlienTrappedEvent) GetAlien() string {
+	if x != nil {
+		return x.Alien
+	}
+	return ""
+}
+
+type CityHasNoNeighborsEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+}
+
+func (x *CityHasNoNeighborsEvent) Reset() {
+	*x = CityHasNoNeighborsEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CityHasNoNeighborsEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityHasNoNeighborsEvent) ProtoMessage() {}
+
+func (x *CityHasNoNeighborsEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityHasNoNeighborsEvent.ProtoReflect.Descriptor instead.
+func (*CityHasNoNeighborsEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CityHasNoNeighborsEvent) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type RoadCutEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// direction of the road from the city: North, East, South or West.
+	Direction string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	Neighbor  string `protobuf:"bytes,3,opt,name=neighbor,proto3" json:"neighbor,omitempty"`
+}
+
+func (x *RoadCutEvent) Reset() {
+	*x = RoadCutEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoadCutEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadCutEvent) ProtoMessage() {}
+
+func (x *RoadCutEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadCutEvent.ProtoReflect.Descriptor instead.
+func (*RoadCutEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RoadCutEvent) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *RoadCutEvent) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *RoadCutEvent) GetNeighbor() string {
+	if x != nil {
+		return x.Neighbor
+	}
+	return ""
+}
+
+type RoadBuiltEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// direction of the road from the city: North, East, South or West.
+	Direction string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	Neighbor  string `protobuf:"bytes,3,opt,name=neighbor,proto3" json:"neighbor,omitempty"`
+}
+
+func (x *RoadBuiltEvent) Reset() {
+	*x = RoadBuiltEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoadBuiltEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoadBuiltEvent) ProtoMessage() {}
+
+func (x *RoadBuiltEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoadBuiltEvent.ProtoReflect.Descriptor instead.
+func (*RoadBuiltEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RoadBuiltEvent) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *RoadBuiltEvent) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *RoadBuiltEvent) GetNeighbor() string {
+	if x != nil {
+		return x.Neighbor
+	}
+	return ""
+}
+
+type AlienKilledEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City  string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Alien string `protobuf:"bytes,2,opt,name=alien,proto3" json:"alien,omitempty"`
+}
+
+func (x *AlienKilledEvent) Reset() {
+	*x = AlienKilledEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlienKilledEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlienKilledEvent) ProtoMessage() {}
+
+func (x *AlienKilledEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlienKilledEvent.ProtoReflect.Descriptor instead.
+func (*AlienKilledEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AlienKilledEvent) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *AlienKilledEvent) GetAlien() string {
+	if x != nil {
+		return x.Alien
+	}
+	return ""
+}
+
+type AlienTeleportedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From  string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Alien string `protobuf:"bytes,3,opt,name=alien,proto3" json:"alien,omitempty"`
+}
+
+func (x *AlienTeleportedEvent) Reset() {
+	*x = AlienTeleportedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlienTeleportedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlienTeleportedEvent) ProtoMessage() {}
+
+func (x *AlienTeleportedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlienTeleportedEvent.ProtoReflect.Descriptor instead.
+func (*AlienTeleportedEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AlienTeleportedEvent) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *AlienTeleportedEvent) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *AlienTeleportedEvent) GetAlien() string {
+	if x != nil {
+		return x.Alien
+	}
+	return ""
+}
+
+type CityRebuiltEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// city is the rebuilt city with its restored roads.
+	City *City `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+}
+
+func (x *CityRebuiltEvent) Reset() {
+	*x = CityRebuiltEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CityRebuiltEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityRebuiltEvent) ProtoMessage() {}
+
+func (x *CityRebuiltEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityRebuiltEvent.ProtoReflect.Descriptor instead.
+func (*CityRebuiltEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CityRebuiltEvent) GetCity() *City {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+type CityFoundedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// city is the founded city with its roads.
+	City *City `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+}
+
+func (x *CityFoundedEvent) Reset() {
+	*x = CityFoundedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aliengame_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CityFoundedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CityFoundedEvent) ProtoMessage() {}
+
+func (x *CityFoundedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aliengame_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CityFoundedEvent.ProtoReflect.Descriptor instead.
+func (*CityFoundedEvent) Descriptor() ([]byte, []int) {
+	return file_aliengame_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CityFoundedEvent) GetCity() *City {
+	if x != nil {
+		return x.City
+	}
+	return nil
+}
+
+var File_aliengame_proto protoreflect.FileDescriptor
+
+var file_aliengame_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x57, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x70, 0x12, 0x2f, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x22, 0x4b, 0x0a, 0x05, 0x57, 0x6f, 0x72, 0x6c,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x12, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x41, 0x6c,
+	0x69, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x77,
+	0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77,
+	0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x42, 0x0a, 0x13,
+	0x53, 0x70, 0x61, 0x77, 0x6e, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x52, 0x06, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x73,
+	0x22, 0x28, 0x0a, 0x0b, 0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x78, 0x0a, 0x0c, 0x53, 0x74,
+	0x65, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x61,
+	0x6e, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x74, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x69, 0x74,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0xbd, 0x01, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x12, 0x25,
+	0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x49, 0x74, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x38, 0x0a, 0x0a, 0x73, 0x74,
+	0x65, 0x70, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x74, 0x65, 0x70, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x22, 0x77, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x31, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x43, 0x0a,
+	0x09, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x22, 0x2a, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x22, 0x2c,
+	0x0a, 0x0f, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6c, 0x64, 0x49, 0x64, 0x22, 0xc7, 0x01, 0x0a,
+	0x0d, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x03,
+	0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x6c, 0x69, 0x65,
+	0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x70, 0x52, 0x03, 0x6d, 0x61,
+	0x70, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x52, 0x06, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x73, 0x12, 0x46,
+	0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x65, 0x64, 0x5f, 0x63, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e,
+	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x65,
+	0x64, 0x43, 0x69, 0x74, 0x79, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x65, 0x64,
+	0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x31, 0x0a, 0x03, 0x4d, 0x61, 0x70, 0x12, 0x2a, 0x0a,
+	0x06, 0x63, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x74,
+	0x79, 0x52, 0x06, 0x63, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xc3, 0x01, 0x0a, 0x04, 0x43, 0x69,
+	0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62,
+	0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x6c, 0x69, 0x65,
+	0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x2e, 0x4e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x68, 0x61, 0x73, 0x5f, 0x6e,
+	0x6f, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x68, 0x61, 0x73, 0x4e, 0x6f, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x76, 0x0a, 0x05, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x63, 0x69, 0x74, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x6f, 0x76,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d,
+	0x6f, 0x76, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x74,
+	0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73,
+	0x54, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x22, 0x75, 0x0a, 0x0d, 0x44, 0x65, 0x73, 0x74, 0x72,
+	0x6f, 0x79, 0x65, 0x64, 0x43, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x69, 0x74, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6c,
+	0x69, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6c, 0x69, 0x65,
+	0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x22, 0xcd,
+	0x05, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x49, 0x0a, 0x0e, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x65, 0x73, 0x74, 0x72,
+	0x6f, 0x79, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x6c, 0x69,
+	0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x44, 0x65,
+	0x73, 0x74, 0x72, 0x6f, 0x79, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0d,
+	0x63, 0x69, 0x74, 0x79, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x65, 0x64, 0x12, 0x46, 0x0a,
+	0x0d, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x54, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x54, 0x72,
+	0x61, 0x70, 0x70, 0x65, 0x64, 0x12, 0x5a, 0x0a, 0x15, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x68, 0x61,
+	0x73, 0x5f, 0x6e, 0x6f, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x48, 0x61, 0x73, 0x4e, 0x6f, 0x4e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x72, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x12, 0x63,
+	0x69, 0x74, 0x79, 0x48, 0x61, 0x73, 0x4e, 0x6f, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72,
+	0x73, 0x12, 0x37, 0x0a, 0x08, 0x72, 0x6f, 0x61, 0x64, 0x5f, 0x63, 0x75, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x6f, 0x61, 0x64, 0x43, 0x75, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x07, 0x72, 0x6f, 0x61, 0x64, 0x43, 0x75, 0x74, 0x12, 0x3d, 0x0a, 0x0a, 0x72, 0x6f,
+	0x61, 0x64, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f,
+	0x61, 0x64, 0x42, 0x75, 0x69, 0x6c, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x09,
+	0x72, 0x6f, 0x61, 0x64, 0x42, 0x75, 0x69, 0x6c, 0x74, 0x12, 0x43, 0x0a, 0x0c, 0x61, 0x6c, 0x69,
+	0x65, 0x6e, 0x5f, 0x6b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x6c, 0x69, 0x65, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48,
+	0x00, 0x52, 0x0b, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x4f,
+	0x0a, 0x10, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x5f, 0x74, 0x65, 0x6c, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e,
+	0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x54, 0x65, 0x6c,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0f,
+	0x61, 0x6c, 0x69, 0x65, 0x6e, 0x54, 0x65, 0x6c, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12,
+	0x43, 0x0a, 0x0c, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x74,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x69, 0x74, 0x79, 0x52, 0x65, 0x62,
+	0x75, 0x69, 0x6c, 0x74, 0x12, 0x43, 0x0a, 0x0c, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6c, 0x69,
+	0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x46, 0x6f,
+	0x75, 0x6e, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x63, 0x69,
+	0x74, 0x79, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x65, 0x78, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x40,
+	0x0a, 0x12, 0x43, 0x69, 0x74, 0x79, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x65, 0x64, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6c, 0x69, 0x65,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x73,
+	0x22, 0x3d, 0x0a, 0x11, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x54, 0x72, 0x61, 0x70, 0x70, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x22,
+	0x2d, 0x0a, 0x17, 0x43, 0x69, 0x74, 0x79, 0x48, 0x61, 0x73, 0x4e, 0x6f, 0x4e, 0x65, 0x69, 0x67,
+	0x68, 0x62, 0x6f, 0x72, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x69,
+	0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x22, 0x5c,
+	0x0a, 0x0c, 0x52, 0x6f, 0x61, 0x64, 0x43, 0x75, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69,
+	0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x22, 0x5e, 0x0a, 0x0e,
+	0x52, 0x6f, 0x61, 0x64, 0x42, 0x75, 0x69, 0x6c, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x69,
+	0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x22, 0x3c, 0x0a, 0x10,
+	0x41, 0x6c, 0x69, 0x65, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x22, 0x50, 0x0a, 0x14, 0x41, 0x6c,
+	0x69, 0x65, 0x6e, 0x54, 0x65, 0x6c, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x22, 0x3a, 0x0a, 0x10,
+	0x43, 0x69, 0x74, 0x79, 0x52, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x26, 0x0a, 0x04, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69,
+	0x74, 0x79, 0x52, 0x04, 0x63, 0x69, 0x74, 0x79, 0x22, 0x3a, 0x0a, 0x10, 0x43, 0x69, 0x74, 0x79,
+	0x46, 0x6f, 0x75, 0x6e, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x6c, 0x69,
+	0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x52, 0x04,
+	0x63, 0x69, 0x74, 0x79, 0x32, 0xa4, 0x03, 0x0a, 0x09, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x47, 0x61,
+	0x6d, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6c,
+	0x64, 0x12, 0x20, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x12, 0x52, 0x0a, 0x0b, 0x53, 0x70, 0x61, 0x77,
+	0x6e, 0x41, 0x6c, 0x69, 0x65, 0x6e, 0x73, 0x12, 0x20, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67,
+	0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x41, 0x6c, 0x69, 0x65,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x6c, 0x69, 0x65,
+	0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x61, 0x77, 0x6e, 0x41, 0x6c,
+	0x69, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x04,
+	0x53, 0x74, 0x65, 0x70, 0x12, 0x19, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x65, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x74, 0x65, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x03, 0x52,
+	0x75, 0x6e, 0x12, 0x18, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61,
+	0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x06, 0x47, 0x65, 0x74,
+	0x4d, 0x61, 0x70, 0x12, 0x1b, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x61, 0x70, 0x12, 0x46, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x1d, 0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f,
+	0x72, 0x6c, 0x64, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x3d, 0x5a, 0x3b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6c, 0x67, 0x6f, 0x6f, 0x7a,
+	0x2f, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x2f, 0x61, 0x6c, 0x69, 0x65, 0x6e, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x61,
+	0x6c, 0x69, 0x65, 0x6e, 0x67, 0x61, 0x6d, 0x65, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_aliengame_proto_rawDescOnce sync.Once
+	file_aliengame_proto_rawDescData = file_aliengame_proto_rawDesc
+)
+
+func file_aliengame_proto_rawDescGZIP() []byte {
+	file_aliengame_proto_rawDescOnce.Do(func() {
+		file_aliengame_proto_rawDescData = protoimpl.X.CompressGZIP(file_aliengame_proto_rawDescData)
+	})
+	return file_aliengame_proto_rawDescData
+}
+
+var file_aliengame_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_aliengame_proto_goTypes = []interface{}{
+	(*CreateWorldRequest)(nil),      // 0: aliengame.v1.CreateWorldRequest
+	(*World)(nil),                   // 1: aliengame.v1.World
+	(*SpawnAliensRequest)(nil),      // 2: aliengame.v1.SpawnAliensRequest
+	(*SpawnAliensResponse)(nil),     // 3: aliengame.v1.SpawnAliensResponse
+	(*StepRequest)(nil),             // 4: aliengame.v1.StepRequest
+	(*StepResponse)(nil),            // 5: aliengame.v1.StepResponse
+	(*RunRequest)(nil),              // 6: aliengame.v1.RunRequest
+	(*RunResponse)(nil),             // 7: aliengame.v1.RunResponse
+	(*RunResult)(nil),               // 8: aliengame.v1.RunResult
+	(*GetMapRequest)(nil),           // 9: aliengame.v1.GetMapRequest
+	(*SnapshotRequest)(nil),         // 10: aliengame.v1.SnapshotRequest
+	(*WorldSnapshot)(nil),           // 11: aliengame.v1.WorldSnapshot
+	(*Map)(nil),                     // 12: aliengame.v1.Map
+	(*City)(nil),                    // 13: aliengame.v1.City
+	(*Alien)(nil),                   // 14: aliengame.v1.Alien
+	(*DestroyedCity)(nil),           // 15: aliengame.v1.DestroyedCity
+	(*Event)(nil),                   // 16: aliengame.v1.Event
+	(*CityDestroyedEvent)(nil),      // 17: aliengame.v1.CityDestroyedEvent
+	(*AlienTrappedEvent)(nil),       // 18: aliengame.v1.AlienTrappedEvent
+	(*CityHasNoNeighborsEvent)(nil), // 19: aliengame.v1.CityHasNoNeighborsEvent
+	(*RoadCutEvent)(nil),            // 20: aliengame.v1.RoadCutEvent
+	(*RoadBuiltEvent)(nil),          // 21: aliengame.v1.RoadBuiltEvent
+	(*AlienKilledEvent)(nil),        // 22: aliengame.v1.AlienKilledEvent
+	(*AlienTeleportedEvent)(nil),    // 23: aliengame.v1.AlienTeleportedEvent
+	(*CityRebuiltEvent)(nil),        // 24: aliengame.v1.CityRebuiltEvent
+	(*CityFoundedEvent)(nil),        // 25: aliengame.v1.CityFoundedEvent
+	nil,                             // 26: aliengame.v1.City.NeighborsEntry
+	(*wrappers.Int64Value)(nil),     // 27: google.protobuf.Int64Value
+	(*duration.Duration)(nil),       // 28: google.protobuf.Duration
+}
+var file_aliengame_proto_depIdxs = []int32{
+	27, // 0: aliengame.v1.CreateWorldRequest.seed:type_name -> google.protobuf.Int64Value
+	14, // 1: aliengame.v1.SpawnAliensResponse.aliens:type_name -> aliengame.v1.Alien
+	16, // 2: aliengame.v1.StepResponse.events:type_name -> aliengame.v1.Event
+	28, // 3: aliengame.v1.RunRequest.timeout:type_name -> google.protobuf.Duration
+	28, // 4: aliengame.v1.RunRequest.step_delay:type_name -> google.protobuf.Duration
+	16, // 5: aliengame.v1.RunResponse.event:type_name -> aliengame.v1.Event
+	8,  // 6: aliengame.v1.RunResponse.result:type_name -> aliengame.v1.RunResult
+	12, // 7: aliengame.v1.WorldSnapshot.map:type_name -> aliengame.v1.Map
+	14, // 8: aliengame.v1.WorldSnapshot.aliens:type_name -> aliengame.v1.Alien
+	15, // 9: aliengame.v1.WorldSnapshot.destroyed_cities:type_name -> aliengame.v1.DestroyedCity
+	13, // 10: aliengame.v1.Map.cities:type_name -> aliengame.v1.City
+	26, // 11: aliengame.v1.City.neighbors:type_name -> aliengame.v1.City.NeighborsEntry
+	17, // 12: aliengame.v1.Event.city_destroyed:type_name -> aliengame.v1.CityDestroyedEvent
+	18, // 13: aliengame.v1.Event.alien_trapped:type_name -> aliengame.v1.AlienTrappedEvent
+	19, // 14: aliengame.v1.Event.city_has_no_neighbors:type_name -> aliengame.v1.CityHasNoNeighborsEvent
+	20, // 15: aliengame.v1.Event.road_cut:type_name -> aliengame.v1.RoadCutEvent
+	21, // 16: aliengame.v1.Event.road_built:type_name -> aliengame.v1.RoadBuiltEvent
+	22, // 17: aliengame.v1.Event.alien_killed:type_name -> aliengame.v1.AlienKilledEvent
+	23, // 18: aliengame.v1.Event.alien_teleported:type_name -> aliengame.v1.AlienTeleportedEvent
+	24, // 19: aliengame.v1.Event.city_rebuilt:type_name -> aliengame.v1.CityRebuiltEvent
+	25, // 20: aliengame.v1.Event.city_founded:type_name -> aliengame.v1.CityFoundedEvent
+	13, // 21: aliengame.v1.CityRebuiltEvent.city:type_name -> aliengame.v1.City
+	13, // 22: aliengame.v1.CityFoundedEvent.city:type_name -> aliengame.v1.City
+	0,  // 23: aliengame.v1.AlienGame.CreateWorld:input_type -> aliengame.v1.CreateWorldRequest
+	2,  // 24: aliengame.v1.AlienGame.SpawnAliens:input_type -> aliengame.v1.SpawnAliensRequest
+	4,  // 25: aliengame.v1.AlienGame.Step:input_type -> aliengame.v1.StepRequest
+	6,  // 26: aliengame.v1.AlienGame.Run:input_type -> aliengame.v1.RunRequest
+	9,  // 27: aliengame.v1.AlienGame.GetMap:input_type -> aliengame.v1.GetMapRequest
+	10, // 28: aliengame.v1.AlienGame.Snapshot:input_type -> aliengame.v1.SnapshotRequest
+	1,  // 29: aliengame.v1.AlienGame.CreateWorld:output_type -> aliengame.v1.World
+	3,  // 30: aliengame.v1.AlienGame.SpawnAliens:output_type -> aliengame.v1.SpawnAliensResponse
+	5,  // 31: aliengame.v1.AlienGame.Step:output_type -> aliengame.v1.StepResponse
+	7,  // 32: aliengame.v1.AlienGame.Run:output_type -> aliengame.v1.RunResponse
+	12, // 33: aliengame.v1.AlienGame.GetMap:output_type -> aliengame.v1.Map
+	11, // 34: aliengame.v1.AlienGame.Snapshot:output_type -> aliengame.v1.WorldSnapshot
+	29, // [29:35] is the sub-list for method output_type
+	23, // [23:29] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
+}
+
+func init() { file_aliengame_proto_init() }
+func file_aliengame_proto_init() {
+	if File_aliengame_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_aliengame_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateWorldRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*World); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpawnAliensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SpawnAliensResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StepResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetMapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorldSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Map); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*City); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alien); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestroyedCity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityDestroyedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlienTrappedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityHasNoNeighborsEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoadCutEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoadBuiltEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlienKilledEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlienTeleportedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityRebuiltEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aliengame_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityFoundedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_aliengame_proto_msgTypes[7].OneofWrappers = []interface{}{
+		(*RunResponse_Event)(nil),
+		(*RunResponse_Result)(nil),
+	}
+	file_aliengame_proto_msgTypes[16].OneofWrappers = []interface{}{
+		(*Event_CityDestroyed)(nil),
+		(*Event_AlienTrapped)(nil),
+		(*Event_CityHasNoNeighbors)(nil),
+		(*Event_RoadCut)(nil),
+		(*Event_RoadBuilt)(nil),
+		(*Event_AlienKilled)(nil),
+		(*Event_AlienTeleported)(nil),
+		(*Event_CityRebuilt)(nil),
+		(*Event_CityFounded)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aliengame_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   27,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aliengame_proto_goTypes,
+		DependencyIndexes: file_aliengame_proto_depIdxs,
+		MessageInfos:      file_aliengame_proto_msgTypes,
+	}.Build()
+	File_aliengame_proto = out.File
+	file_aliengame_proto_rawDesc = nil
+	file_aliengame_proto_goTypes = nil
+	file_aliengame_proto_depIdxs = nil
+}
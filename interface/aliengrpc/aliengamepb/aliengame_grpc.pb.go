@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package aliengamepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// AlienGameClient is the client API for AlienGame service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AlienGameClient interface {
+	// CreateWorld creates a new world from a map defination.
+	CreateWorld(ctx context.Context, in *CreateWorldRequest, opts ...grpc.CallOption) (*World, error)
+	// SpawnAliens spawns aliens at random cities of the world.
+	SpawnAliens(ctx context.Context, in *SpawnAliensRequest, opts ...grpc.CallOption) (*SpawnAliensResponse, error)
+	// Step resumes the world for one iteration.
+	Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*StepResponse, error)
+	// Run runs the world till the end or till one of the limits is reached.
+	// events are streamed as they happen and the result is sent last.
+	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (AlienGame_RunClient, error)
+	// GetMap gets the current map of the world.
+	GetMap(ctx context.Context, in *GetMapRequest, opts ...grpc.CallOption) (*Map, error)
+	// Snapshot gets the current state of the world.
+	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*WorldSnapshot, error)
+}
+
+type alienGameClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlienGameClient(cc grpc.ClientConnInterface) AlienGameClient {
+	return &alienGameClient{cc}
+}
+
+func (c *alienGameClient) CreateWorld(ctx context.Context, in *CreateWorldRequest, opts ...grpc.CallOption) (*World, error) {
+	out := new(World)
+	err := c.cc.Invoke(ctx, "/aliengame.v1.AlienGame/CreateWorld", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienGameClient) SpawnAliens(ctx context.Context, in *SpawnAliensRequest, opts ...grpc.CallOption) (*SpawnAliensResponse, error) {
+	out := new(SpawnAliensResponse)
+	err := c.cc.Invoke(ctx, "/aliengame.v1.AlienGame/SpawnAliens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienGameClient) Step(ctx context.Context, in *StepRequest, opts ...grpc.CallOption) (*StepResponse, error) {
+	out := new(StepResponse)
+	err := c.cc.Invoke(ctx, "/aliengame.v1.AlienGame/Step", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienGameClient) Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (AlienGame_RunClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AlienGame_serviceDesc.Streams[0], "/aliengame.v1.AlienGame/Run", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &alienGameRunClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AlienGame_RunClient interface {
+	Recv() (*RunResponse, error)
+	grpc.ClientStream
+}
+
+type alienGameRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *alienGameRunClient) Recv() (*RunResponse, error) {
+	m := new(RunResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *alienGameClient) GetMap(ctx context.Context, in *GetMapRequest, opts ...grpc.CallOption) (*Map, error) {
+	out := new(Map)
+	err := c.cc.Invoke(ctx, "/aliengame.v1.AlienGame/GetMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alienGameClient) Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*WorldSnapshot, error) {
+	out := new(WorldSnapshot)
+	err := c.cc.Invoke(ctx, "/aliengame.v1.AlienGame/Snapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AlienGameServer is the server API for AlienGame service.
+// All implementations must embed UnimplementedAlienGameServer
+// for forward compatibility
+type AlienGameServer interface {
+	// CreateWorld creates a new world from a map defination.
+	CreateWorld(context.Context, *CreateWorldRequest) (*World, error)
+	// SpawnAliens spawns aliens at random cities of the world.
+	SpawnAliens(context.Context, *SpawnAliensRequest) (*SpawnAliensResponse, error)
+	// Step resumes the world for one iteration.
+	Step(context.Context, *StepRequest) (*StepResponse, error)
+	// Run runs the world till the end or till one of the limits is reached.
+	// events are streamed as they happen and the result is sent last.
+	Run(*RunRequest, AlienGame_RunServer) error
+	// GetMap gets the current map of the world.
+	GetMap(context.Context, *GetMapRequest) (*Map, error)
+	// Snapshot gets the current state of the world.
+	Snapshot(context.Context, *SnapshotRequest) (*WorldSnapshot, error)
+	mustEmbedUnimplementedAlienGameServer()
+}
+
+// UnimplementedAlienGameServer must be embedded to have forward compatible implementations.
+type UnimplementedAlienGameServer struct {
+}
+
+func (UnimplementedAlienGameServer) CreateWorld(context.Context, *CreateWorldRequest) (*World, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorld not implemented")
+}
+func (UnimplementedAlienGameServer) SpawnAliens(context.Context, *SpawnAliensRequest) (*SpawnAliensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SpawnAliens not implemented")
+}
+func (UnimplementedAlienGameServer) Step(context.Context, *StepRequest) (*StepResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Step not implemented")
+}
+func (UnimplementedAlienGameServer) Run(*RunRequest, AlienGame_RunServer) error {
+	return status.Errorf(codes.Unimplemented, "method Run not implemented")
+}
+func (UnimplementedAlienGameServer) GetMap(context.Context, *GetMapRequest) (*Map, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMap not implemented")
+}
+func (UnimplementedAlienGameServer) Snapshot(context.Context, *SnapshotRequest) (*WorldSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Snapshot not implemented")
+}
+func (UnimplementedAlienGameServer) mustEmbedUnimplementedAlienGameServer() {}
+
+// UnsafeAlienGameServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlienGameServer will
+// result in compilation errors.
+type UnsafeAlienGameServer interface {
+	mustEmbedUnimplementedAlienGameServer()
+}
+
+func RegisterAlienGameServer(s grpc.ServiceRegistrar, srv AlienGameServer) {
+	s.RegisterService(&_AlienGame_serviceDesc, srv)
+}
+
+func _AlienGame_CreateWorld_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienGameServer).CreateWorld(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliengame.v1.AlienGame/CreateWorld",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienGameServer).CreateWorld(ctx, req.(*CreateWorldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienGame_SpawnAliens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpawnAliensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienGameServer).SpawnAliens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliengame.v1.AlienGame/SpawnAliens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienGameServer).SpawnAliens(ctx, req.(*SpawnAliensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienGame_Step_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StepRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienGameServer).Step(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliengame.v1.AlienGame/Step",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienGameServer).Step(ctx, req.(*StepRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienGame_Run_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlienGameServer).Run(m, &alienGameRunServer{stream})
+}
+
+type AlienGame_RunServer interface {
+	Send(*RunResponse) error
+	grpc.ServerStream
+}
+
+type alienGameRunServer struct {
+	grpc.ServerStream
+}
+
+func (x *alienGameRunServer) Send(m *RunResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AlienGame_GetMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienGameServer).GetMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliengame.v1.AlienGame/GetMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienGameServer).GetMap(ctx, req.(*GetMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AlienGame_Snapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlienGameServer).Snapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/aliengame.v1.AlienGame/Snapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlienGameServer).Snapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AlienGame_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "aliengame.v1.AlienGame",
+	HandlerType: (*AlienGameServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWorld",
+			Handler:    _AlienGame_CreateWorld_Handler,
+		},
+		{
+			MethodName: "SpawnAliens",
+			Handler:    _AlienGame_SpawnAliens_Handler,
+		},
+		{
+			MethodName: "Step",
+			Handler:    _AlienGame_Step_Handler,
+		},
+		{
+			MethodName: "GetMap",
+			Handler:    _AlienGame_GetMap_Handler,
+		},
+		{
+			MethodName: "Snapshot",
+			Handler:    _AlienGame_Snapshot_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Run",
+			Handler:       _AlienGame_Run_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "aliengame.proto",
+}
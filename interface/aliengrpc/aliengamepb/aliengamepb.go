@@ -0,0 +1,5 @@
+// Package aliengamepb contains the generated protobuf messages and gRPC service
+// of the AlienGame service defined in aliengame.proto.
+package aliengamepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative aliengame.proto
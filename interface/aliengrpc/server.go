@@ -0,0 +1,319 @@
+// Package aliengrpc is a gRPC interface to create and control games remotely.
+// see aliengamepb/aliengame.proto for the AlienGame service defination.
+//
+//	s := grpc.NewServer()
+//	aliengamepb.RegisterAlienGameServer(s, aliengrpc.New())
+package aliengrpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/interface/aliengrpc/aliengamepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// eventBufferSize is the number of events buffered for each Run stream.
+// streams that cannot keep up with the game are ended.
+const eventBufferSize = 256
+
+// maxSpawnCount is the max number of aliens that can be spawned at once.
+const maxSpawnCount = 10000
+
+// Server implements the AlienGame service and manages worlds in memory.
+type Server struct {
+	aliengamepb.UnimplementedAlienGameServer
+
+	mw     sync.Mutex // protects following.
+	worlds map[string]*aliengame.World
+}
+
+// New creates a new server.
+func New() *Server {
+	return &Server{
+		worlds: make(map[string]*aliengame.World),
+	}
+}
+
+func (s *Server) world(id string) (*aliengame.World, error) {
+	s.mw.Lock()
+	defer s.mw.Unlock()
+	world, ok := s.worlds[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "world %q not found", id)
+	}
+	return world, nil
+}
+
+// resumableWorld gets the world and makes sure that it has not ended.
+func (s *Server) resumableWorld(id string) (*aliengame.World, error) {
+	world, err := s.world(id)
+	if err != nil {
+		return nil, err
+	}
+	if world.Ended() {
+		return nil, status.Errorf(codes.FailedPrecondition, "world %q has ended", id)
+	}
+	return world, nil
+}
+
+// CreateWorld implements aliengamepb.AlienGameServer.
+func (s *Server) CreateWorld(ctx context.Context, req *aliengamepb.CreateWorldRequest) (*aliengamepb.World, error) {
+	mp, err := aliengame.ParseMap(strings.NewReader(req.Map))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := aliengame.CraftMap(mp); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var options []aliengame.Option
+	if req.Seed != nil {
+		options = append(options, aliengame.WithSeed(req.Seed.Value))
+	}
+	id, err := newID()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	world := aliengame.New(mp, nil, options...)
+	s.mw.Lock()
+	s.worlds[id] = world
+	s.mw.Unlock()
+	return &aliengamepb.World{
+		Id:        id,
+		Iteration: int32(world.Iteration()),
+		Ended:     world.Ended(),
+	}, nil
+}
+
+// SpawnAliens implements aliengamepb.AlienGameServer.
+func (s *Server) SpawnAliens(ctx context.Context, req *aliengamepb.SpawnAliensRequest) (*aliengamepb.SpawnAliensResponse, error) {
+	if req.Count < 0 || req.Count > maxSpawnCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be a number between 0 and %d", maxSpawnCount)
+	}
+	world, err := s.resumableWorld(req.WorldId)
+	if err != nil {
+		return nil, err
+	}
+	if world.MapView().Len() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "there are no cities left to spawn aliens")
+	}
+	world.SpawnAlien(int(req.Count))
+	return &aliengamepb.SpawnAliensResponse{Aliens: toAliens(world.Aliens())}, nil
+}
+
+// Step implements aliengamepb.AlienGameServer.
+func (s *Server) Step(ctx context.Context, req *aliengamepb.StepRequest) (*aliengamepb.StepResponse, error) {
+	world, err := s.resumableWorld(req.WorldId)
+	if err != nil {
+		return nil, err
+	}
+	var canResume bool
+	events := world.Collect(func() {
+		canResume = world.Resume()
+	})
+	res := &aliengamepb.StepResponse{
+		CanResume: canResume,
+		Iteration: int32(world.Iteration()),
+	}
+	for _, event := range events {
+		res.Events = append(res.Events, toEvent(event))
+	}
+	return res, nil
+}
+
+// Run implements aliengamepb.AlienGameServer.
+func (s *Server) Run(req *aliengamepb.RunRequest, stream aliengamepb.AlienGame_RunServer) error {
+	if req.MaxIterations < 0 {
+		return status.Error(codes.InvalidArgument, "max iterations must be a non-negative number")
+	}
+	for _, d := range []*durationpb.Duration{req.Timeout, req.StepDelay} {
+		if d != nil && !d.IsValid() {
+			return status.Error(codes.InvalidArgument, d.CheckValid().Error())
+		}
+	}
+	world, err := s.resumableWorld(req.WorldId)
+	if err != nil {
+		return err
+	}
+	opts := aliengame.RunOptions{
+		MaxIterations: int(req.MaxIterations),
+		Timeout:       req.Timeout.AsDuration(),
+		StepDelay:     req.StepDelay.AsDuration(),
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	sub := world.Subscribe(eventBufferSize)
+	resultC := make(chan aliengame.RunResult, 1)
+	go func() {
+		result, _ := world.Run(ctx, opts)
+		sub.Cancel()
+		resultC <- result
+	}()
+	for event := range sub.C {
+		err := stream.Send(&aliengamepb.RunResponse{
+			Update: &aliengamepb.RunResponse_Event{Event: toEvent(event)},
+		})
+		if err != nil {
+			cancel()
+			<-resultC
+			return err
+		}
+	}
+	result := <-resultC
+	if sub.Dropped() {
+		return status.Error(codes.ResourceExhausted, "stream could not keep up with the game")
+	}
+	if err := stream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return stream.Send(&aliengamepb.RunResponse{
+		Update: &aliengamepb.RunResponse_Result{Result: &aliengamepb.RunResult{
+			Reason:     string(result.Reason),
+			Iterations: int32(result.Iterations),
+		}},
+	})
+}
+
+// GetMap implements aliengamepb.AlienGameServer.
+func (s *Server) GetMap(ctx context.Context, req *aliengamepb.GetMapRequest) (*aliengamepb.Map, error) {
+	world, err := s.world(req.WorldId)
+	if err != nil {
+		return nil, err
+	}
+	return toMap(world.Map()), nil
+}
+
+// Snapshot implements aliengamepb.AlienGameServer.
+func (s *Server) Snapshot(ctx context.Context, req *aliengamepb.SnapshotRequest) (*aliengamepb.WorldSnapshot, error) {
+	world, err := s.world(req.WorldId)
+	if err != nil {
+		return nil, err
+	}
+	frame := world.Frame()
+	snapshot := &aliengamepb.WorldSnapshot{
+		Iteration: int32(frame.Iteration),
+		Map:       toMap(frame.Map),
+		Aliens:    toAliens(frame.Aliens),
+	}
+	for _, city := range world.Stats().DestroyedCities {
+		snapshot.DestroyedCities = append(snapshot.DestroyedCities, &aliengamepb.DestroyedCity{
+			Name:      city.Name,
+			Iteration: int32(city.Iteration),
+			Aliens:    city.Aliens,
+			External:  city.External,
+		})
+	}
+	return snapshot, nil
+}
+
+// toMap converts mp to its protobuf message with cities sorted by name.
+func toMap(mp aliengame.Map) *aliengamepb.Map {
+	var cityNames []string
+	for cityName := range mp {
+		cityNames = append(cityNames, cityName)
+	}
+	sort.Strings(cityNames)
+	m := &aliengamepb.Map{}
+	for _, cityName := range cityNames {
+		m.Cities = append(m.Cities, toCity(mp[cityName]))
+	}
+	return m
+}
+
+func toCity(city *aliengame.City) *aliengamepb.City {
+	c := &aliengamepb.City{
+		Name:           city.Name,
+		Neighbors:      make(map[string]string),
+		HasNoNeighbors: city.HasNoNeighbors,
+	}
+	for direction, neighborName := range city.Neighbors {
+		c.Neighbors[string(direction)] = neighborName
+	}
+	return c
+}
+
+func toAliens(aliens []aliengame.Alien) []*aliengamepb.Alien {
+	var pbAliens []*aliengamepb.Alien
+	for _, alien := range aliens {
+		pbAliens = append(pbAliens, &aliengamepb.Alien{
+			Name:      alien.Name,
+			CityName:  alien.CityName,
+			MoveCount: int32(alien.MoveCount),
+			IsTrapped: alien.IsTrapped,
+		})
+	}
+	return pbAliens
+}
+
+// toEvent converts a game event to its protobuf message.
+func toEvent(e aliengame.Event) *aliengamepb.Event {
+	event := &aliengamepb.Event{
+		Message:  e.String(),
+		External: aliengame.IsExternal(e),
+	}
+	switch e := e.(type) {
+	case aliengame.CityDestroyedEvent:
+		destroyed := &aliengamepb.CityDestroyedEvent{City: e.City.Name}
+		for _, alien := range e.Aliens {
+			destroyed.Aliens = append(destroyed.Aliens, alien.Name)
+		}
+		event.Event = &aliengamepb.Event_CityDestroyed{CityDestroyed: destroyed}
+	case aliengame.AlienTrappedEvent:
+		event.Event = &aliengamepb.Event_AlienTrapped{AlienTrapped: &aliengamepb.AlienTrappedEvent{
+			City:  e.City.Name,
+			Alien: e.Alien.Name,
+		}}
+	case aliengame.CityHasNoNeighborsEvent:
+		event.Event = &aliengamepb.Event_CityHasNoNeighbors{CityHasNoNeighbors: &aliengamepb.CityHasNoNeighborsEvent{
+			City: e.City.Name,
+		}}
+	case aliengame.RoadCutEvent:
+		event.Event = &aliengamepb.Event_RoadCut{RoadCut: &aliengamepb.RoadCutEvent{
+			City:      e.City.Name,
+			Direction: string(e.Direction),
+			Neighbor:  e.Neighbor.Name,
+		}}
+	case aliengame.RoadBuiltEvent:
+		event.Event = &aliengamepb.Event_RoadBuilt{RoadBuilt: &aliengamepb.RoadBuiltEvent{
+			City:      e.City.Name,
+			Direction: string(e.Direction),
+			Neighbor:  e.Neighbor.Name,
+		}}
+	case aliengame.AlienKilledEvent:
+		event.Event = &aliengamepb.Event_AlienKilled{AlienKilled: &aliengamepb.AlienKilledEvent{
+			City:  e.City.Name,
+			Alien: e.Alien.Name,
+		}}
+	case aliengame.AlienTeleportedEvent:
+		event.Event = &aliengamepb.Event_AlienTeleported{AlienTeleported: &aliengamepb.AlienTeleportedEvent{
+			From:  e.From.Name,
+			To:    e.To.Name,
+			Alien: e.Alien.Name,
+		}}
+	case aliengame.CityRebuiltEvent:
+		event.Event = &aliengamepb.Event_CityRebuilt{CityRebuilt: &aliengamepb.CityRebuiltEvent{
+			City: toCity(e.City),
+		}}
+	case aliengame.CityFoundedEvent:
+		event.Event = &aliengamepb.Event_CityFounded{CityFounded: &aliengamepb.CityFoundedEvent{
+			City: toCity(e.City),
+		}}
+	}
+	return event
+}
+
+// newID generates a random world id.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
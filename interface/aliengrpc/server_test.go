@@ -0,0 +1,175 @@
+package aliengrpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/interface/aliengrpc/aliengamepb"
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testMapdef = `
+Foo north=Bar west=Baz south=Qu-ux
+Bee south=Bar
+Yee west=Bar
+`
+
+// newTestClient serves a new server in-process and connects to it.
+func newTestClient(t *testing.T) aliengamepb.AlienGameClient {
+	l := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	aliengamepb.RegisterAlienGameServer(s, New())
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return aliengamepb.NewAlienGameClient(conn)
+}
+
+func createWorld(t *testing.T, client aliengamepb.AlienGameClient, aliens int32) string {
+	ctx := context.Background()
+	world, err := client.CreateWorld(ctx, &aliengamepb.CreateWorldRequest{
+		Map:  testMapdef,
+		Seed: &wrappers.Int64Value{Value: 1},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, world.Id)
+	require.Equal(t, int32(0), world.Iteration)
+	require.False(t, world.Ended)
+	spawned, err := client.SpawnAliens(ctx, &aliengamepb.SpawnAliensRequest{WorldId: world.Id, Count: aliens})
+	require.NoError(t, err)
+	require.Len(t, spawned.Aliens, int(aliens))
+	return world.Id
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	id := createWorld(t, client, 1)
+
+	mp, err := client.GetMap(ctx, &aliengamepb.GetMapRequest{WorldId: id})
+	require.NoError(t, err)
+	require.Len(t, mp.Cities, 6)
+	require.Equal(t, "Bar", mp.Cities[0].Name)
+	require.Equal(t, map[string]string{"North": "Bee", "East": "Yee", "South": "Foo"}, mp.Cities[0].Neighbors)
+
+	step, err := client.Step(ctx, &aliengamepb.StepRequest{WorldId: id})
+	require.NoError(t, err)
+	require.True(t, step.CanResume)
+	require.Equal(t, int32(1), step.Iteration)
+
+	snapshot, err := client.Snapshot(ctx, &aliengamepb.SnapshotRequest{WorldId: id})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), snapshot.Iteration)
+	require.Len(t, snapshot.Aliens, 1)
+	require.Equal(t, int32(1), snapshot.Aliens[0].MoveCount)
+
+	stream, err := client.Run(ctx, &aliengamepb.RunRequest{WorldId: id, MaxIterations: 2})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, &aliengamepb.RunResult{Reason: "iteration-limit-reached", Iterations: 3}, res.GetResult())
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestServerRun(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	id := createWorld(t, client, 6)
+
+	stream, err := client.Run(ctx, &aliengamepb.RunRequest{WorldId: id})
+	require.NoError(t, err)
+	var (
+		destroyed []string
+		result    *aliengamepb.RunResult
+	)
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Nil(t, result, "result must be sent last")
+		if e := res.GetEvent().GetCityDestroyed(); e != nil {
+			require.NotEmpty(t, res.GetEvent().Message)
+			destroyed = append(destroyed, e.City)
+		}
+		result = res.GetResult()
+	}
+	require.NotNil(t, result)
+	require.NotEqual(t, "iteration-limit-reached", result.Reason)
+
+	snapshot, err := client.Snapshot(ctx, &aliengamepb.SnapshotRequest{WorldId: id})
+	require.NoError(t, err)
+	require.Equal(t, result.Iterations, snapshot.Iteration)
+	require.Len(t, snapshot.DestroyedCities, len(destroyed))
+	for i, city := range snapshot.DestroyedCities {
+		require.Equal(t, destroyed[i], city.Name)
+	}
+
+	_, err = client.Step(ctx, &aliengamepb.StepRequest{WorldId: id})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestServerErrors(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	_, err := client.CreateWorld(ctx, &aliengamepb.CreateWorldRequest{Map: "Foo up=Bar"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Step(ctx, &aliengamepb.StepRequest{WorldId: "none"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetMap(ctx, &aliengamepb.GetMapRequest{WorldId: "none"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	id := createWorld(t, client, 0)
+	_, err = client.SpawnAliens(ctx, &aliengamepb.SpawnAliensRequest{WorldId: id, Count: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.SpawnAliens(ctx, &aliengamepb.SpawnAliensRequest{WorldId: id, Count: maxSpawnCount + 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	stream, err := client.Run(ctx, &aliengamepb.RunRequest{WorldId: id, MaxIterations: -1})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestToEvent(t *testing.T) {
+	foo := &aliengame.City{Name: "Foo", Neighbors: map[compass.Direction]string{compass.North: "Bar"}}
+	bar := &aliengame.City{Name: "Bar"}
+	alien := &aliengame.Alien{Name: "A1", CityName: "Foo"}
+
+	event := toEvent(aliengame.RoadCutEvent{City: foo, Direction: compass.North, Neighbor: bar})
+	require.True(t, event.External)
+	require.Equal(t, "North", event.GetRoadCut().Direction)
+	require.Equal(t, "Bar", event.GetRoadCut().Neighbor)
+
+	event = toEvent(aliengame.AlienTeleportedEvent{From: foo, To: bar, Alien: alien})
+	require.Equal(t, "Bar", event.GetAlienTeleported().To)
+
+	event = toEvent(aliengame.CityDestroyedEvent{City: foo, Aliens: []*aliengame.Alien{alien}, External: true})
+	require.True(t, event.External)
+	event = toEvent(aliengame.CityDestroyedEvent{City: foo})
+	require.False(t, event.External)
+
+	event = toEvent(aliengame.CityFoundedEvent{City: foo})
+	require.False(t, event.External)
+	require.Equal(t, map[string]string{"North": "Bar"}, event.GetCityFounded().City.Neighbors)
+	require.NotEmpty(t, event.Message)
+}
@@ -0,0 +1,425 @@
+// Package alienhttp is an HTTP/JSON interface to create and play games over
+// the network.
+//
+// Endpoints:
+//
+//	POST   /games?aliens=N[&seed=S][&turnTimeout=D]
+//	                                  creates a game from the map defination in the body.
+//	GET    /games/{id}                gets the status of the game.
+//	DELETE /games/{id}                deletes the game.
+//	POST   /games/{id}/step           resumes the game for one iteration.
+//	POST   /games/{id}/run            runs the game till the end, accepts maxIterations and timeout queries.
+//	GET    /games/{id}/map            gets the current map, as text with format=text query.
+//	GET    /games/{id}/aliens         gets the living aliens.
+//	GET    /games/{id}/players        gets the scores of players.
+//	POST   /games/{id}/players?name=P&aliens=A1,A2
+//	                                  adds a player controlling the aliens.
+//	POST   /games/{id}/moves?player=P&alien=A&direction=D
+//	                                  moves the alien of the player at the next iteration.
+//	GET    /games/{id}/events         streams the game events with Server-Sent Events.
+//	GET    /games/{id}/ws             streams the game events and state diffs over WebSocket, see alienws.
+package alienhttp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/interface/alienws"
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+// eventBufferSize is the number of events buffered for each event stream.
+// streams that cannot keep up with the game are ended.
+const eventBufferSize = 256
+
+// maxAlienCount is the max number of aliens that a game can be created with.
+const maxAlienCount = 10000
+
+// maxMapSize is the max size of a map defination in bytes.
+const maxMapSize = 1 << 20
+
+// Server is an HTTP server that manages games in memory.
+type Server struct {
+	mg    sync.Mutex // protects following.
+	games map[string]*game
+}
+
+// game is a game managed by the server.
+type game struct {
+	id    string
+	world *aliengame.World
+
+	ms sync.Mutex // protects following.
+	// result is the result of the last run.
+	result *aliengame.RunResult
+}
+
+// New creates a new server.
+func New() *Server {
+	return &Server{
+		games: make(map[string]*game),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "games" || len(parts) > 3 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if len(parts) == 1 {
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodPost: s.createGame,
+		})
+		return
+	}
+	g, ok := s.game(parts[1])
+	if !ok {
+		writeError(w, http.StatusNotFound, "game not found")
+		return
+	}
+	var action string
+	if len(parts) == 3 {
+		action = parts[2]
+	}
+	var handlers map[string]http.HandlerFunc
+	switch action {
+	case "":
+		handlers = map[string]http.HandlerFunc{
+			http.MethodGet:    g.getStatus,
+			http.MethodDelete: func(w http.ResponseWriter, r *http.Request) { s.deleteGame(w, r, g) },
+		}
+	case "step":
+		handlers = map[string]http.HandlerFunc{http.MethodPost: g.step}
+	case "run":
+		handlers = map[string]http.HandlerFunc{http.MethodPost: g.run}
+	case "map":
+		handlers = map[string]http.HandlerFunc{http.MethodGet: g.getMap}
+	case "aliens":
+		handlers = map[string]http.HandlerFunc{http.MethodGet: g.getAliens}
+	case "players":
+		handlers = map[string]http.HandlerFunc{
+			http.MethodGet:  g.getPlayers,
+			http.MethodPost: g.addPlayer,
+		}
+	case "moves":
+		handlers = map[string]http.HandlerFunc{http.MethodPost: g.move}
+	case "events":
+		handlers = map[string]http.HandlerFunc{http.MethodGet: g.streamEvents}
+	case "ws":
+		handlers = map[string]http.HandlerFunc{http.MethodGet: g.spectate}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	s.route(w, r, handlers)
+}
+
+// route calls the handler of the request method.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, handlers map[string]http.HandlerFunc) {
+	handler, ok := handlers[r.Method]
+	if !ok {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	handler(w, r)
+}
+
+func (s *Server) game(id string) (*game, bool) {
+	s.mg.Lock()
+	defer s.mg.Unlock()
+	g, ok := s.games[id]
+	return g, ok
+}
+
+// createGame creates a new game from the map defination in the request body.
+func (s *Server) createGame(w http.ResponseWriter, r *http.Request) {
+	alienCount, err := strconv.Atoi(r.URL.Query().Get("aliens"))
+	if err != nil || alienCount < 0 || alienCount > maxAlienCount {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("aliens query must be a number between 0 and %d", maxAlienCount))
+		return
+	}
+	var options []aliengame.Option
+	if seedQuery := r.URL.Query().Get("seed"); seedQuery != "" {
+		seed, err := strconv.ParseInt(seedQuery, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "seed query must be a number")
+			return
+		}
+		options = append(options, aliengame.WithSeed(seed))
+	}
+	if q := r.URL.Query().Get("turnTimeout"); q != "" {
+		turnTimeout, err := time.ParseDuration(q)
+		if err != nil || turnTimeout <= 0 {
+			writeError(w, http.StatusBadRequest, "turnTimeout query must be a positive duration")
+			return
+		}
+		options = append(options, aliengame.WithTurnTimeout(turnTimeout))
+	}
+	mp, err := aliengame.ParseMap(http.MaxBytesReader(w, r.Body, maxMapSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := aliengame.CraftMap(mp); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id, err := newID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	g := &game{
+		id:    id,
+		world: aliengame.New(mp, nil, options...),
+	}
+	g.world.SpawnAlien(alienCount)
+	s.mg.Lock()
+	s.games[id] = g
+	s.mg.Unlock()
+	w.Header().Set("Location", "/games/"+id)
+	writeJSON(w, http.StatusCreated, g.status())
+}
+
+// deleteGame ends and deletes the game.
+func (s *Server) deleteGame(w http.ResponseWriter, r *http.Request, g *game) {
+	s.mg.Lock()
+	delete(s.games, g.id)
+	s.mg.Unlock()
+	// end the game so event streams are closed.
+	g.world.End()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// gameStatus is the json response of a game status.
+type gameStatus struct {
+	ID string `json:"id"`
+
+	// CanResume is false when the game has ended.
+	CanResume bool `json:"canResume"`
+
+	// Result is the result of the last step or run.
+	Result *aliengame.RunResult `json:"result,omitempty"`
+
+	Stats aliengame.Stats `json:"stats"`
+}
+
+func (g *game) status() gameStatus {
+	stats := g.world.Stats()
+	g.ms.Lock()
+	defer g.ms.Unlock()
+	return gameStatus{
+		ID:        g.id,
+		CanResume: !g.ended(),
+		Result:    g.result,
+		Stats:     stats,
+	}
+}
+
+// ended checks if the world has ended, or the last run has ended the game
+// without ending the world. g.ms must be held by the caller.
+func (g *game) ended() bool {
+	if g.world.Ended() {
+		return true
+	}
+	if g.result == nil {
+		return false
+	}
+	switch g.result.Reason {
+	case aliengame.IterationLimitReached, aliengame.Cancelled:
+		return false
+	}
+	return true
+}
+
+func (g *game) getStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, g.status())
+}
+
+// step resumes the game for one iteration.
+func (g *game) step(w http.ResponseWriter, r *http.Request) {
+	g.runGame(w, r, aliengame.RunOptions{MaxIterations: 1})
+}
+
+// run runs the game till the end or till one of the given limits is reached.
+func (g *game) run(w http.ResponseWriter, r *http.Request) {
+	var opts aliengame.RunOptions
+	query := r.URL.Query()
+	if q := query.Get("maxIterations"); q != "" {
+		maxIterations, err := strconv.Atoi(q)
+		if err != nil || maxIterations < 1 {
+			writeError(w, http.StatusBadRequest, "maxIterations query must be a positive number")
+			return
+		}
+		opts.MaxIterations = maxIterations
+	}
+	if q := query.Get("timeout"); q != "" {
+		timeout, err := time.ParseDuration(q)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "timeout query must be a duration")
+			return
+		}
+		opts.Timeout = timeout
+	}
+	g.runGame(w, r, opts)
+}
+
+// runGame runs the game with opts and responds with the game status. game is
+// cancelled when the client goes away.
+func (g *game) runGame(w http.ResponseWriter, r *http.Request, opts aliengame.RunOptions) {
+	g.ms.Lock()
+	ended := g.ended()
+	g.ms.Unlock()
+	if ended {
+		writeError(w, http.StatusConflict, "game has ended")
+		return
+	}
+	result, _ := g.world.Run(r.Context(), opts)
+	g.ms.Lock()
+	g.result = &result
+	g.ms.Unlock()
+	writeJSON(w, http.StatusOK, g.status())
+}
+
+// getMap responds with the current map as json, or in the map defination
+// format with the format=text query.
+func (g *game) getMap(w http.ResponseWriter, r *http.Request) {
+	mp := g.world.Map()
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		aliengame.PrintMap(w, mp)
+		return
+	}
+	writeJSON(w, http.StatusOK, mp)
+}
+
+func (g *game) getAliens(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, g.world.Aliens())
+}
+
+// getPlayers responds with the scores of players.
+func (g *game) getPlayers(w http.ResponseWriter, r *http.Request) {
+	scores := g.world.Scores()
+	if scores == nil {
+		scores = []aliengame.Score{}
+	}
+	writeJSON(w, http.StatusOK, scores)
+}
+
+// addPlayer adds a player to control the aliens in the aliens query.
+func (g *game) addPlayer(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var alienNames []string
+	if q := query.Get("aliens"); q != "" {
+		alienNames = strings.Split(q, ",")
+	}
+	if err := g.world.AddPlayer(query.Get("name"), alienNames...); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, g.world.Scores())
+}
+
+// move submits the move of a player. the game waits for moves of all players
+// while it is running.
+func (g *game) move(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	direction, ok := compass.ParseDirection(query.Get("direction"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "direction query must be a compass direction")
+		return
+	}
+	if err := g.world.Move(query.Get("player"), query.Get("alien"), direction); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// streamEvents streams the game events as Server-Sent Events till the game ends
+// or the client goes away. the stream is ended early if the client cannot keep
+// up with the game.
+func (g *game) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+	sub := g.world.Subscribe(eventBufferSize)
+	defer sub.Cancel()
+	g.ms.Lock()
+	ended := g.ended()
+	g.ms.Unlock()
+	if ended {
+		writeError(w, http.StatusGone, "game has ended")
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				fmt.Fprint(w, "event: end\ndata: {}\n\n")
+				flusher.Flush()
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "event: game\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+// spectate lets spectators watch the game over WebSocket.
+func (g *game) spectate(w http.ResponseWriter, r *http.Request) {
+	g.ms.Lock()
+	ended := g.ended()
+	g.ms.Unlock()
+	if ended {
+		writeError(w, http.StatusGone, "game has ended")
+		return
+	}
+	alienws.Handler(g.world, alienws.Options{EventBuffer: eventBufferSize}).ServeHTTP(w, r)
+}
+
+// newID generates a random game id.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// errorResponse is the json response of errors.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, errorResponse{message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
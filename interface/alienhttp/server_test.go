@@ -0,0 +1,226 @@
+package alienhttp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+const testMapdef = `
+Foo north=Bar west=Baz south=Qu-ux
+Bee south=Bar
+Yee west=Bar
+`
+
+func do(t *testing.T, method, url, body string, v interface{}) *http.Response {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	if v != nil {
+		require.NoError(t, json.NewDecoder(res.Body).Decode(v))
+	}
+	return res
+}
+
+func createGame(t *testing.T, url string, query string) gameStatus {
+	var status gameStatus
+	res := do(t, http.MethodPost, url+"/games?"+query, testMapdef, &status)
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	require.Equal(t, "/games/"+status.ID, res.Header.Get("Location"))
+	return status
+}
+
+func TestServer(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+
+	status := createGame(t, ts.URL, "aliens=1&seed=1")
+	require.True(t, status.CanResume)
+	gameURL := ts.URL + "/games/" + status.ID
+
+	var aliens []aliengame.Alien
+	do(t, http.MethodGet, gameURL+"/aliens", "", &aliens)
+	require.Len(t, aliens, 1)
+
+	var mp aliengame.Map
+	do(t, http.MethodGet, gameURL+"/map", "", &mp)
+	require.Len(t, mp, 6)
+	res, err := http.Get(gameURL + "/map?format=text")
+	require.NoError(t, err)
+	text, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	require.True(t, strings.HasPrefix(string(text), "Bar east=Yee north=Bee"), string(text))
+
+	res = do(t, http.MethodPost, gameURL+"/step", "", &status)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, 1, status.Stats.Iterations)
+
+	do(t, http.MethodPost, gameURL+"/run?maxIterations=3", "", &status)
+	require.Equal(t, aliengame.IterationLimitReached, status.Result.Reason)
+	require.True(t, status.CanResume)
+	require.Equal(t, 4, status.Stats.Iterations)
+
+	do(t, http.MethodPost, gameURL+"/run", "", &status)
+	require.False(t, status.CanResume)
+
+	res = do(t, http.MethodPost, gameURL+"/step", "", nil)
+	require.Equal(t, http.StatusConflict, res.StatusCode)
+
+	res = do(t, http.MethodDelete, gameURL, "", nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+	res = do(t, http.MethodGet, gameURL, "", nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestServerErrors(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	cases := []struct {
+		method string
+		path   string
+		body   string
+		code   int
+	}{
+		{http.MethodPost, "/games", testMapdef, http.StatusBadRequest},
+		{http.MethodPost, "/games?aliens=1&seed=x", testMapdef, http.StatusBadRequest},
+		{http.MethodPost, "/games?aliens=1&turnTimeout=x", testMapdef, http.StatusBadRequest},
+		{http.MethodPost, "/games?aliens=1", "Foo north", http.StatusBadRequest},
+		{http.MethodPost, "/games?aliens=1", "", http.StatusBadRequest},
+		{http.MethodPost, "/games?aliens=10001", testMapdef, http.StatusBadRequest},
+		{http.MethodPost, "/games?aliens=1", strings.Repeat("Foo north=Bar\n", maxMapSize/10), http.StatusBadRequest},
+		{http.MethodGet, "/games", "", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/games/nope", "", http.StatusNotFound},
+		{http.MethodGet, "/nope", "", http.StatusNotFound},
+	}
+	for _, tt := range cases {
+		var e errorResponse
+		res := do(t, tt.method, ts.URL+tt.path, tt.body, &e)
+		require.Equal(t, tt.code, res.StatusCode, tt.path)
+		require.NotEmpty(t, e.Error)
+	}
+}
+
+func TestServerPlayers(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	status := createGame(t, ts.URL, "aliens=1&seed=1&turnTimeout=1m")
+	gameURL := ts.URL + "/games/" + status.ID
+
+	var scores []aliengame.Score
+	do(t, http.MethodGet, gameURL+"/players", "", &scores)
+	require.Empty(t, scores)
+	res := do(t, http.MethodPost, gameURL+"/players?name=p1&aliens=A1", "", &scores)
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+	require.Len(t, scores, 1)
+	res = do(t, http.MethodPost, gameURL+"/players?name=p2&aliens=A1", "", nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// pick a neighbor of the alien's city to move.
+	var aliens []aliengame.Alien
+	do(t, http.MethodGet, gameURL+"/aliens", "", &aliens)
+	var mp aliengame.Map
+	do(t, http.MethodGet, gameURL+"/map", "", &mp)
+	var direction compass.Direction
+	city := mp[aliens[0].CityName]
+	for _, direction = range compass.Directions {
+		if _, ok := city.Neighbors[direction]; ok {
+			break
+		}
+	}
+
+	// step waits for the move of the player.
+	stepped := make(chan gameStatus)
+	go func() {
+		var status gameStatus
+		do(t, http.MethodPost, gameURL+"/step", "", &status)
+		stepped <- status
+	}()
+	res = do(t, http.MethodPost, gameURL+"/moves?player=p2&alien=A1&direction="+string(direction), "", nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	res = do(t, http.MethodPost, gameURL+"/moves?player=p1&alien=A1&direction=up", "", nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	res = do(t, http.MethodPost, gameURL+"/moves?player=p1&alien=A1&direction="+string(direction), "", nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+	status = <-stepped
+	require.Equal(t, 1, status.Stats.Iterations)
+
+	do(t, http.MethodGet, gameURL+"/aliens", "", &aliens)
+	require.Equal(t, city.Neighbors[direction], aliens[0].CityName)
+	do(t, http.MethodGet, gameURL+"/players", "", &scores)
+	require.Equal(t, 1, scores[0].SurvivedIterations)
+}
+
+func TestServerEvents(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	status := createGame(t, ts.URL, "aliens=6")
+	gameURL := ts.URL + "/games/" + status.ID
+
+	res, err := http.Get(gameURL + "/events")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	do(t, http.MethodPost, gameURL+"/run", "", &status)
+	require.False(t, status.CanResume)
+
+	// read events till the end of the stream.
+	var names []string
+	var destroyed int
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "event: ") {
+			names = append(names, strings.TrimPrefix(line, "event: "))
+		}
+		if strings.HasPrefix(line, "data: ") && strings.Contains(line, `"type":"city-destroyed"`) {
+			destroyed++
+		}
+	}
+	require.NotEmpty(t, names)
+	require.Equal(t, "end", names[len(names)-1])
+	require.Equal(t, len(status.Stats.DestroyedCities), destroyed)
+
+	res = do(t, http.MethodGet, gameURL+"/events", "", nil)
+	require.Equal(t, http.StatusGone, res.StatusCode)
+}
+
+func TestServerSpectate(t *testing.T) {
+	ts := httptest.NewServer(New())
+	defer ts.Close()
+	status := createGame(t, ts.URL, "aliens=6")
+	gameURL := ts.URL + "/games/" + status.ID
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(gameURL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	var m struct {
+		Type string `json:"type"`
+	}
+	require.NoError(t, conn.ReadJSON(&m))
+	require.Equal(t, "snapshot", m.Type)
+
+	do(t, http.MethodPost, gameURL+"/run", "", &status)
+	require.False(t, status.CanResume)
+	for {
+		if err := conn.ReadJSON(&m); err != nil {
+			require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+			break
+		}
+	}
+	require.Equal(t, "end", m.Type)
+
+	res := do(t, http.MethodGet, gameURL+"/ws", "", nil)
+	require.Equal(t, http.StatusGone, res.StatusCode)
+}
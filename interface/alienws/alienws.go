@@ -0,0 +1,283 @@
+// Package alienws is a WebSocket interface to watch games live. any number of
+// spectators can watch the same world without slowing it down.
+//
+// spectators receive JSON messages:
+//
+//	{"type": "snapshot", ...}  the full state of the world once they connect.
+//	{"type": "event", ...}     each game event as it happens.
+//	{"type": "state", ...}     periodic diffs of the world state, only when it changes.
+//	{"type": "end"}            once the world ends, then the connection is closed.
+//
+// spectators that cannot keep up with the game are disconnected with the
+// policy violation close code.
+package alienws
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+)
+
+const (
+	defaultStateInterval = time.Second
+	defaultEventBuffer   = 256
+	defaultWriteTimeout  = 10 * time.Second
+)
+
+// Options configures the spectator handler. zero value of each option means
+// its default.
+type Options struct {
+	// StateInterval is the interval to send state diffs, 1s by default.
+	StateInterval time.Duration
+
+	// EventBuffer is the number of events that can wait to be sent to a
+	// spectator, 256 by default. spectators are disconnected when their buffer
+	// is full.
+	EventBuffer int
+
+	// WriteTimeout is the max duration to send a message to a spectator, 10s
+	// by default. spectators are disconnected when it is exceeded.
+	WriteTimeout time.Duration
+}
+
+// message types sent to spectators.
+const (
+	typeSnapshot = "snapshot"
+	typeEvent    = "event"
+	typeState    = "state"
+	typeEnd      = "end"
+)
+
+// message is a message sent to spectators with no payload.
+type message struct {
+	Type string `json:"type"`
+}
+
+// eventMessage carries a game event.
+type eventMessage struct {
+	Type  string          `json:"type"`
+	Event aliengame.Event `json:"event"`
+}
+
+// snapshot is the full state of a world.
+type snapshot struct {
+	Type      string            `json:"type"`
+	Iteration int               `json:"iteration"`
+	Map       aliengame.Map     `json:"map"`
+	Aliens    []aliengame.Alien `json:"aliens"`
+}
+
+// diff is the changes in the state of a world between two frames.
+type diff struct {
+	Type           string            `json:"type"`
+	Iteration      int               `json:"iteration"`
+	RemovedCities  []string          `json:"removedCities,omitempty"`
+	AddedCities    []*aliengame.City `json:"addedCities,omitempty"`
+	RemovedAliens  []string          `json:"removedAliens,omitempty"`
+	AddedAliens    []aliengame.Alien `json:"addedAliens,omitempty"`
+	ChangedAliens  []aliengame.Alien `json:"changedAliens,omitempty"`
+	RemovedRoads   []road            `json:"removedRoads,omitempty"`
+	AddedRoads     []road            `json:"addedRoads,omitempty"`
+	NoNeighborsNow []string          `json:"noNeighborsNow,omitempty"`
+}
+
+// empty checks if there is no change other than the iteration.
+func (d *diff) empty() bool {
+	return len(d.RemovedCities) == 0 && len(d.AddedCities) == 0 && len(d.RemovedAliens) == 0 &&
+		len(d.AddedAliens) == 0 && len(d.ChangedAliens) == 0 && len(d.RemovedRoads) == 0 &&
+		len(d.AddedRoads) == 0 && len(d.NoNeighborsNow) == 0
+}
+
+// newDiff finds the changes from the old frame to the new one. cities appear
+// when they are rebuilt or founded, and aliens when they are spawned during the
+// game. added cities come with their roads in the added roads too.
+func newDiff(old, new aliengame.Frame) *diff {
+	d := &diff{Type: typeState, Iteration: new.Iteration}
+	for cityName, city := range old.Map {
+		newCity, ok := new.Map[cityName]
+		if !ok {
+			d.RemovedCities = append(d.RemovedCities, cityName)
+			continue
+		}
+		if newCity.HasNoNeighbors && !city.HasNoNeighbors {
+			d.NoNeighborsNow = append(d.NoNeighborsNow, cityName)
+		}
+	}
+	for cityName, city := range new.Map {
+		if _, ok := old.Map[cityName]; !ok {
+			d.AddedCities = append(d.AddedCities, city)
+		}
+	}
+	oldRoads, newRoads := roadSet(old.Map), roadSet(new.Map)
+	for road := range oldRoads {
+		if !newRoads[road] {
+			d.RemovedRoads = append(d.RemovedRoads, road)
+		}
+	}
+	for road := range newRoads {
+		if !oldRoads[road] {
+			d.AddedRoads = append(d.AddedRoads, road)
+		}
+	}
+	newAliens := make(map[string]aliengame.Alien)
+	for _, alien := range new.Aliens {
+		newAliens[alien.Name] = alien
+	}
+	for _, alien := range old.Aliens {
+		newAlien, ok := newAliens[alien.Name]
+		switch {
+		case !ok:
+			d.RemovedAliens = append(d.RemovedAliens, alien.Name)
+		case newAlien != alien:
+			d.ChangedAliens = append(d.ChangedAliens, newAlien)
+		}
+		delete(newAliens, alien.Name)
+	}
+	for _, alien := range newAliens {
+		d.AddedAliens = append(d.AddedAliens, alien)
+	}
+	sort.Strings(d.RemovedCities)
+	sort.Slice(d.AddedCities, func(i, j int) bool { return d.AddedCities[i].Name < d.AddedCities[j].Name })
+	sort.Strings(d.RemovedAliens)
+	sort.Slice(d.AddedAliens, func(i, j int) bool { return d.AddedAliens[i].Name < d.AddedAliens[j].Name })
+	sort.Strings(d.NoNeighborsNow)
+	sort.Slice(d.ChangedAliens, func(i, j int) bool { return d.ChangedAliens[i].Name < d.ChangedAliens[j].Name })
+	sortRoads(d.RemovedRoads)
+	sortRoads(d.AddedRoads)
+	return d
+}
+
+// road is a road from the city to the neighbor in the direction. the road back
+// from the neighbor is a separate road, so the roads between the same cities
+// in different directions, and the roads moved to other directions are not
+// missed.
+type road struct {
+	City      string            `json:"city"`
+	Direction compass.Direction `json:"direction"`
+	Neighbor  string            `json:"neighbor"`
+}
+
+// roadSet returns the roads of the map.
+func roadSet(mp aliengame.Map) map[road]bool {
+	roads := make(map[road]bool)
+	for cityName, city := range mp {
+		for direction, neighborName := range city.Neighbors {
+			roads[road{cityName, direction, neighborName}] = true
+		}
+	}
+	return roads
+}
+
+func sortRoads(roads []road) {
+	sort.Slice(roads, func(i, j int) bool {
+		if roads[i].City != roads[j].City {
+			return roads[i].City < roads[j].City
+		}
+		return roads[i].Direction < roads[j].Direction
+	})
+}
+
+var upgrader = websocket.Upgrader{
+	// spectators can watch from any origin.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// Handler returns an http.Handler that lets spectators watch the world over
+// WebSocket connections.
+func Handler(world *aliengame.World, opts Options) http.Handler {
+	if opts.StateInterval <= 0 {
+		opts.StateInterval = defaultStateInterval
+	}
+	if opts.EventBuffer <= 0 {
+		opts.EventBuffer = defaultEventBuffer
+	}
+	if opts.WriteTimeout <= 0 {
+		opts.WriteTimeout = defaultWriteTimeout
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// upgrader already responded with the error.
+			return
+		}
+		defer conn.Close()
+		spectate(conn, world, opts)
+	})
+}
+
+// spectate sends the world state and events to the spectator till the world
+// ends, spectator goes away or falls behind.
+func spectate(conn *websocket.Conn, world *aliengame.World, opts Options) {
+	sub := world.Subscribe(opts.EventBuffer)
+	defer sub.Cancel()
+	frame := world.Frame()
+
+	// read the messages of the spectator to process control messages and to
+	// know when it goes away.
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(m interface{}) bool {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return false
+		}
+		conn.SetWriteDeadline(time.Now().Add(opts.WriteTimeout))
+		return conn.WriteMessage(websocket.TextMessage, data) == nil
+	}
+	closeWith := func(code int, text string) {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text),
+			time.Now().Add(opts.WriteTimeout))
+	}
+
+	if !write(snapshot{typeSnapshot, frame.Iteration, frame.Map, frame.Aliens}) {
+		return
+	}
+	ticker := time.NewTicker(opts.StateInterval)
+	defer ticker.Stop()
+	sendDiff := func() bool {
+		newFrame := world.Frame()
+		d := newDiff(frame, newFrame)
+		frame = newFrame
+		if d.empty() {
+			return true
+		}
+		return write(d)
+	}
+	for {
+		select {
+		case <-gone:
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				if sub.Dropped() {
+					closeWith(websocket.ClosePolicyViolation, "slow consumer")
+					return
+				}
+				if sendDiff() && write(message{typeEnd}) {
+					closeWith(websocket.CloseNormalClosure, "world has ended")
+				}
+				return
+			}
+			if !write(eventMessage{typeEvent, event}) {
+				return
+			}
+		case <-ticker.C:
+			if !sendDiff() {
+				return
+			}
+		}
+	}
+}
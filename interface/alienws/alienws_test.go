@@ -0,0 +1,221 @@
+package alienws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/ilgooz/aliengame/aliengame"
+	"github.com/ilgooz/aliengame/x/compass"
+	"github.com/stretchr/testify/require"
+)
+
+const testMapdef = `
+Foo north=Bar west=Baz south=Qu-ux
+Bee south=Bar
+Yee west=Bar
+`
+
+func newTestWorld(t *testing.T, alienCount int) *aliengame.World {
+	mp, err := aliengame.ParseMap(strings.NewReader(testMapdef))
+	require.NoError(t, err)
+	require.NoError(t, aliengame.CraftMap(mp))
+	world := aliengame.New(mp, nil, aliengame.WithSeed(1))
+	world.SpawnAlien(alienCount)
+	return world
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(url, "http"), nil)
+	require.NoError(t, err)
+	return conn
+}
+
+// spectatorMessage is the union of all messages sent to spectators.
+type spectatorMessage struct {
+	Type          string            `json:"type"`
+	Iteration     int               `json:"iteration"`
+	Map           aliengame.Map     `json:"map"`
+	Aliens        []aliengame.Alien `json:"aliens"`
+	RemovedCities []string          `json:"removedCities"`
+	Event         json.RawMessage   `json:"event"`
+}
+
+func TestHandler(t *testing.T) {
+	world := newTestWorld(t, 4)
+	ts := httptest.NewServer(Handler(world, Options{StateInterval: time.Millisecond}))
+	defer ts.Close()
+
+	var spectators []*websocket.Conn
+	for i := 0; i < 2; i++ {
+		conn := dial(t, ts.URL)
+		defer conn.Close()
+		var m spectatorMessage
+		require.NoError(t, conn.ReadJSON(&m))
+		require.Equal(t, typeSnapshot, m.Type)
+		require.Len(t, m.Map, 6)
+		require.Len(t, m.Aliens, 4)
+		spectators = append(spectators, conn)
+	}
+
+	_, err := world.Run(context.Background(), aliengame.RunOptions{})
+	require.NoError(t, err)
+	stats := world.Stats()
+
+	for _, conn := range spectators {
+		var (
+			types     []string
+			destroyed int
+			removed   []string
+		)
+		for {
+			var m spectatorMessage
+			err := conn.ReadJSON(&m)
+			if err != nil {
+				require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+				break
+			}
+			types = append(types, m.Type)
+			if m.Type == typeEvent && strings.Contains(string(m.Event), `"type":"city-destroyed"`) {
+				destroyed++
+			}
+			removed = append(removed, m.RemovedCities...)
+		}
+		require.Equal(t, typeEnd, types[len(types)-1])
+		require.Equal(t, len(stats.DestroyedCities), destroyed)
+		require.Len(t, removed, len(stats.DestroyedCities))
+	}
+}
+
+func TestHandlerSlowConsumer(t *testing.T) {
+	// many isolated cities so resuming the world once sends a burst of events.
+	mp := make(aliengame.Map)
+	for i := 0; i < 200; i++ {
+		name := fmt.Sprintf("City%d", i)
+		mp[name] = &aliengame.City{Name: name}
+	}
+	world := aliengame.New(mp, nil, aliengame.WithSeed(1))
+	world.SpawnAlien(200)
+	ts := httptest.NewServer(Handler(world, Options{EventBuffer: 1}))
+	defer ts.Close()
+
+	conn := dial(t, ts.URL)
+	defer conn.Close()
+	var m spectatorMessage
+	require.NoError(t, conn.ReadJSON(&m))
+	require.Equal(t, typeSnapshot, m.Type)
+
+	// world is not blocked by the spectator.
+	world.Resume()
+
+	for {
+		if err := conn.ReadJSON(&m); err != nil {
+			require.True(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation), err)
+			break
+		}
+		require.NotEqual(t, typeEnd, m.Type)
+	}
+}
+
+func TestHandlerEnded(t *testing.T) {
+	world := newTestWorld(t, 1)
+	world.End()
+	ts := httptest.NewServer(Handler(world, Options{}))
+	defer ts.Close()
+
+	conn := dial(t, ts.URL)
+	defer conn.Close()
+	var types []string
+	for {
+		var m spectatorMessage
+		if err := conn.ReadJSON(&m); err != nil {
+			require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure), err)
+			break
+		}
+		types = append(types, m.Type)
+	}
+	require.Equal(t, []string{typeSnapshot, typeEnd}, types)
+}
+
+func TestNewDiff(t *testing.T) {
+	old := aliengame.Frame{
+		Iteration: 1,
+		Map: aliengame.Map{
+			"Foo": {Name: "Foo", Neighbors: map[compass.Direction]string{compass.North: "Bar"}},
+			"Bar": {Name: "Bar", Neighbors: map[compass.Direction]string{compass.South: "Foo", compass.East: "Baz"}},
+			"Baz": {Name: "Baz", Neighbors: map[compass.Direction]string{compass.West: "Bar"}},
+		},
+		Aliens: []aliengame.Alien{
+			{Name: "a", CityName: "Foo"},
+			{Name: "b", CityName: "Baz"},
+			{Name: "c", CityName: "Baz"},
+		},
+	}
+	new := aliengame.Frame{
+		Iteration: 2,
+		Map: aliengame.Map{
+			"Foo": {Name: "Foo", HasNoNeighbors: true, Neighbors: map[compass.Direction]string{}},
+			"Bar": {Name: "Bar", Neighbors: map[compass.Direction]string{compass.East: "Baz"}},
+			"Baz": {Name: "Baz", Neighbors: map[compass.Direction]string{compass.West: "Bar"}},
+		},
+		Aliens: []aliengame.Alien{
+			{Name: "a", CityName: "Foo", IsTrapped: true},
+			{Name: "c", CityName: "Bar", MoveCount: 1},
+		},
+	}
+	d := newDiff(old, new)
+	require.Equal(t, &diff{
+		Type:           typeState,
+		Iteration:      2,
+		RemovedAliens:  []string{"b"},
+		ChangedAliens:  []aliengame.Alien{new.Aliens[0], new.Aliens[1]},
+		RemovedRoads:   []road{{"Bar", compass.South, "Foo"}, {"Foo", compass.North, "Bar"}},
+		NoNeighborsNow: []string{"Foo"},
+	}, d)
+	require.False(t, d.empty())
+	require.True(t, newDiff(new, new).empty())
+
+	// roads built by interventions.
+	new.Map["Foo"].Neighbors[compass.East] = "Baz"
+	new.Map["Baz"].Neighbors[compass.West] = "Foo"
+	delete(new.Map["Bar"].Neighbors, compass.East)
+	d = newDiff(old, new)
+	require.Equal(t, []road{{"Baz", compass.West, "Foo"}, {"Foo", compass.East, "Baz"}}, d.AddedRoads)
+	require.Equal(t, []road{
+		{"Bar", compass.East, "Baz"},
+		{"Bar", compass.South, "Foo"},
+		{"Baz", compass.West, "Bar"},
+		{"Foo", compass.North, "Bar"},
+	}, d.RemovedRoads)
+
+	// roads moved to other directions between the same cities.
+	moved := aliengame.Frame{Iteration: 3, Map: new.Map.Clone(), Aliens: new.Aliens}
+	delete(moved.Map["Foo"].Neighbors, compass.East)
+	moved.Map["Foo"].Neighbors[compass.South] = "Baz"
+	moved.Map["Baz"].Neighbors[compass.North] = "Foo"
+	delete(moved.Map["Baz"].Neighbors, compass.West)
+	d = newDiff(new, moved)
+	require.Equal(t, []road{{"Baz", compass.North, "Foo"}, {"Foo", compass.South, "Baz"}}, d.AddedRoads)
+	require.Equal(t, []road{{"Baz", compass.West, "Foo"}, {"Foo", compass.East, "Baz"}}, d.RemovedRoads)
+
+	delete(new.Map, "Baz")
+	require.Equal(t, []string{"Baz"}, newDiff(old, new).RemovedCities)
+
+	// cities founded and aliens spawned during the game.
+	old, new = new, aliengame.Frame{Iteration: 3, Map: new.Map.Clone(), Aliens: new.Aliens}
+	new.Map["Qux"] = &aliengame.City{Name: "Qux", Neighbors: map[compass.Direction]string{compass.South: "Bar"}}
+	new.Map["Bar"].Neighbors[compass.North] = "Qux"
+	new.Aliens = append(new.Aliens, aliengame.Alien{Name: "d", CityName: "Qux"})
+	d = newDiff(old, new)
+	require.Equal(t, []*aliengame.City{new.Map["Qux"]}, d.AddedCities)
+	require.Equal(t, []aliengame.Alien{{Name: "d", CityName: "Qux"}}, d.AddedAliens)
+	require.Equal(t, []road{{"Bar", compass.North, "Qux"}, {"Qux", compass.South, "Bar"}}, d.AddedRoads)
+	require.Empty(t, d.RemovedCities)
+	require.Empty(t, d.RemovedAliens)
+	require.False(t, d.empty())
+}
@@ -36,6 +36,22 @@ func ReverseDirection(d Direction) Direction {
 	panic("unreachable")
 }
 
+// Offset returns the unit step of d on a 2D grid where x grows to the east and
+// y grows to the south.
+func Offset(d Direction) (dx, dy int) {
+	switch d {
+	case North:
+		return 0, -1
+	case South:
+		return 0, 1
+	case East:
+		return 1, 0
+	case West:
+		return -1, 0
+	}
+	panic("unreachable")
+}
+
 // ParseDirection parses a string value as compass Direction.
 // if string value is not a valid geo value ok will be returned
 // with a false value.
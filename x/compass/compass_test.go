@@ -20,6 +20,22 @@ func TestReverseDirection(t *testing.T) {
 	require.Equal(t, East, ReverseDirection(West))
 }
 
+func TestOffset(t *testing.T) {
+	for _, d := range Directions {
+		dx, dy := Offset(d)
+		rdx, rdy := Offset(ReverseDirection(d))
+		require.Equal(t, 1, dx*dx+dy*dy)
+		require.Equal(t, -dx, rdx)
+		require.Equal(t, -dy, rdy)
+	}
+	dx, dy := Offset(North)
+	require.Equal(t, 0, dx)
+	require.Equal(t, -1, dy)
+	dx, dy = Offset(East)
+	require.Equal(t, 1, dx)
+	require.Equal(t, 0, dy)
+}
+
 func TestParseDirection(t *testing.T) {
 	cases := []struct {
 		s  string